@@ -12,15 +12,39 @@ type CacheFlyClient struct {
 	APIURL string
 	Token  string
 	HTTP   *http.Client
+
+	// clock is used to wait between retries. Tests replace it to avoid sleeping.
+	clock clock
+}
+
+// clock abstracts time so retry backoff can be tested without waiting.
+type clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
 }
 
+type realClock struct{}
+
+func (realClock) Now() time.Time        { return time.Now() }
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
+
 // NewCacheFlyClient creates a new CacheFly client.
 func NewCacheFlyClient(apiURL, token string) *CacheFlyClient {
 	return &CacheFlyClient{
 		APIURL: apiURL,
 		Token:  token,
 		HTTP:   &http.Client{Timeout: 10 * time.Second},
+		clock:  realClock{},
+	}
+}
+
+// timeSource returns the clock of the client, falling back to the system clock for clients
+// that were not built with NewCacheFlyClient.
+func (c *CacheFlyClient) timeSource() clock {
+	if c.clock == nil {
+		return realClock{}
 	}
+	return c.clock
 }
 
 func (c *CacheFlyClient) NewRequest(method, endpoint string) (*http.Request, error) {
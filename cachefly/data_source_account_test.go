@@ -0,0 +1,23 @@
+package cachefly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceCacheflyAccountRead(t *testing.T) {
+	client := newReplayClient(t, "account")
+	d := schema.TestResourceDataRaw(t, dataSourceCacheflyAccount().Schema, map[string]interface{}{})
+
+	if diags := dataSourceCacheflyAccountRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	assertResourceData(t, d, map[string]string{
+		"id":           "5c9a7f1e2b3d4e0012f00d1e",
+		"company_name": "Example Media Ltd",
+		"website":      "https://www.example.com",
+	})
+}
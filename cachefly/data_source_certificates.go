@@ -0,0 +1,176 @@
+package cachefly
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourceCacheflyCertificates lists every certificate of the account with expiry details.
+func dataSourceCacheflyCertificates() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCacheflyCertificatesRead,
+		Schema: map[string]*schema.Schema{
+			"search": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Search term to filter certificates by domain name.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"AUTO_SSL", "CUSTOM"}, false),
+				Description:  "Only return certificates of this type. Possible values: AUTO_SSL, CUSTOM.",
+			},
+			"expiring_within_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Only return certificates that expire within this number of days, including already expired ones.",
+			},
+			"include_expired": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to include certificates that have already expired.",
+			},
+			"certificates": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "A list of certificates with their expiry details.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the certificate.",
+						},
+						"subject_common_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The common name of the certificate subject.",
+						},
+						"domains": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The domains covered by the certificate.",
+						},
+						"issuer": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The issuer of the certificate.",
+						},
+						"not_before": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the certificate becomes valid.",
+						},
+						"not_after": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the certificate expires.",
+						},
+						"days_remaining": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of whole days until the certificate expires. Negative once expired, and -1 when CacheFly reports no valid expiry time.",
+						},
+						"expired": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Indicates whether the certificate has expired.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "AUTO_SSL for certificates provisioned by CacheFly, CUSTOM for uploaded certificates.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCacheflyCertificatesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	queryParams := url.Values{}
+	queryParams.Set("responseType", "full")
+	if search, ok := d.GetOk("search"); ok {
+		queryParams.Set("search", search.(string))
+	}
+
+	data, err := fetchAllPages[Certificate](client, "/api/2.5/certificates", queryParams)
+	if err != nil {
+		return diag.Errorf("failed to fetch certificates: %v", err)
+	}
+
+	certificateType, filterType := d.GetOk("type")
+	expiringWithin, filterExpiring := d.GetOkExists("expiring_within_days")
+	includeExpired := d.Get("include_expired").(bool)
+	now := time.Now()
+
+	certificates := make([]map[string]interface{}, 0, len(data))
+	for _, certificate := range data {
+		expired := certificate.Expired
+		daysRemaining, err := certificateDaysRemaining(certificate, now)
+		if err != nil {
+			// One certificate without a usable expiry must not hide the others.
+			log.Printf("[WARN] %v, reporting days_remaining as -1", err)
+			daysRemaining = -1
+		} else if daysRemaining < 0 {
+			expired = true
+		}
+		typ := "CUSTOM"
+		if certificate.AutoSsl {
+			typ = "AUTO_SSL"
+		}
+
+		if filterType && typ != certificateType.(string) {
+			continue
+		}
+		if filterExpiring && daysRemaining > expiringWithin.(int) {
+			continue
+		}
+		if !includeExpired && expired {
+			continue
+		}
+
+		certificates = append(certificates, map[string]interface{}{
+			"id":                  certificate.ID,
+			"subject_common_name": certificate.SubjectCommonName,
+			"domains":             certificate.SubjectNames,
+			"issuer":              certificate.Issuer,
+			"not_before":          certificate.NotBefore,
+			"not_after":           certificate.NotAfter,
+			"days_remaining":      daysRemaining,
+			"expired":             expired,
+			"type":                typ,
+		})
+	}
+
+	if err := d.Set("certificates", certificates); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("cachefly_certificates")
+	return nil
+}
+
+// certificateDaysRemaining returns the number of whole days between now and the certificate expiry.
+func certificateDaysRemaining(certificate Certificate, now time.Time) (int, error) {
+	notAfter, err := time.Parse(time.RFC3339, certificate.NotAfter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse notAfter of certificate %s: %w", certificate.ID, err)
+	}
+	return int(math.Floor(notAfter.Sub(now).Hours() / 24)), nil
+}
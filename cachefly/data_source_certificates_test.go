@@ -0,0 +1,91 @@
+package cachefly
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceCacheflyCertificatesRead(t *testing.T) {
+	client := newReplayClient(t, "certificates")
+	d := schema.TestResourceDataRaw(t, dataSourceCacheflyCertificates().Schema, map[string]interface{}{
+		"include_expired": false,
+	})
+
+	if diags := dataSourceCacheflyCertificatesRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	assertResourceData(t, d, map[string]string{
+		"certificates.#":                     "1",
+		"certificates.0.id":                  "6512a4f07c1e2b0012ab3c4e",
+		"certificates.0.subject_common_name": "*.example.com",
+		"certificates.0.domains.#":           "1",
+		"certificates.0.domains.0":           "*.example.com",
+		"certificates.0.type":                "CUSTOM",
+		"certificates.0.expired":             "false",
+	})
+}
+
+func TestCertificateDaysRemaining(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]int{
+		"2025-01-11T12:00:00Z": 10,
+		"2025-01-02T11:00:00Z": 0,
+		"2024-12-31T12:00:00Z": -1,
+	}
+	for notAfter, want := range cases {
+		got, err := certificateDaysRemaining(Certificate{NotAfter: notAfter}, now)
+		if err != nil {
+			t.Fatalf("%s: %v", notAfter, err)
+		}
+		if got != want {
+			t.Errorf("%s: expected %d days, got %d", notAfter, want, got)
+		}
+	}
+}
+
+func TestDataSourceCacheflyCertificatesReadInvalidExpiry(t *testing.T) {
+	srv, client, _ := newFaultClient(t)
+	srv.InjectFault(fakeapi.Fault{
+		Method: "GET",
+		Path:   "/api/2.5/certificates",
+		Status: http.StatusOK,
+		Body: `{"meta":{"limit":100,"offset":0,"count":3},"data":[
+			{"_id":"a","subjectCommonName":"missing.example.com","notAfter":""},
+			{"_id":"b","subjectCommonName":"garbled.example.com","notAfter":"next year"},
+			{"_id":"c","subjectCommonName":"valid.example.com","notAfter":"2099-06-12T23:59:59.000Z"}
+		]}`,
+		Times: 1,
+	})
+	d := schema.TestResourceDataRaw(t, dataSourceCacheflyCertificates().Schema, map[string]interface{}{})
+
+	var logs bytes.Buffer
+	previous := log.Writer()
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(previous) })
+
+	if diags := dataSourceCacheflyCertificatesRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	assertResourceData(t, d, map[string]string{
+		"certificates.#":                "3",
+		"certificates.0.days_remaining": "-1",
+		"certificates.0.expired":        "false",
+		"certificates.1.days_remaining": "-1",
+		"certificates.2.id":             "c",
+		"certificates.2.expired":        "false",
+	})
+	if !strings.Contains(logs.String(), "[WARN] failed to parse notAfter of certificate b") {
+		t.Errorf("expected a warning about certificate b, got %q", logs.String())
+	}
+}
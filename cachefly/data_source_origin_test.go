@@ -0,0 +1,26 @@
+package cachefly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceCacheflyOriginsRead(t *testing.T) {
+	client := newReplayClient(t, "origins")
+	d := schema.TestResourceDataRaw(t, dataSourceCacheflyOrigins().Schema, map[string]interface{}{
+		"type": "WEB",
+	})
+
+	if diags := dataSourceCacheflyOriginsRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	assertResourceData(t, d, map[string]string{
+		"origins.#":      "2",
+		"origins.0.id":   "5f2a0b9c1e4d3a0012aa0001",
+		"origins.0.name": "www.example.com",
+		"origins.1.name": "downloads origin",
+	})
+}
@@ -0,0 +1,215 @@
+package cachefly
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// StatsPoint represents the traffic of one period returned by the reporting API.
+type StatsPoint struct {
+	Timestamp   string           `json:"timestamp,omitempty"`
+	Requests    int64            `json:"requests"`
+	Bytes       int64            `json:"bytes"`
+	Hits        int64            `json:"hits"`
+	StatusCodes map[string]int64 `json:"statusCodes,omitempty"`
+}
+
+// ServiceStats represents the reporting API response.
+type ServiceStats struct {
+	Totals StatsPoint   `json:"totals"`
+	Data   []StatsPoint `json:"data"`
+}
+
+// dataSourceCacheflyServiceStats reports traffic statistics of a service or the whole account.
+func dataSourceCacheflyServiceStats() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCacheflyServiceStatsRead,
+		Schema: map[string]*schema.Schema{
+			"service_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of the service to report on. Statistics cover the whole account when omitted.",
+			},
+			"from": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "The RFC3339 start of the reporting period, inclusive.",
+			},
+			"to": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "The RFC3339 end of the reporting period, exclusive.",
+			},
+			"granularity": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "DAY",
+				ValidateFunc: validation.StringInSlice([]string{"HOUR", "DAY", "MONTH"}, false),
+				Description:  "The length of each period in series. Possible values: HOUR, DAY, MONTH.",
+			},
+			"requests": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total number of requests in the reporting period.",
+			},
+			"bandwidth_bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total number of bytes delivered in the reporting period.",
+			},
+			"cache_hit_ratio": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The fraction of requests served from cache, between 0 and 1.",
+			},
+			"status_codes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The number of requests per response status, ordered by status.",
+				Elem:        statusCodeStatsElem(),
+			},
+			"series": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The statistics of each period, ordered by time.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"timestamp": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The start of the period.",
+						},
+						"requests": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of requests in the period.",
+						},
+						"bandwidth_bytes": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of bytes delivered in the period.",
+						},
+						"cache_hit_ratio": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "The fraction of requests served from cache in the period.",
+						},
+						"status_codes": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The number of requests per response status in the period.",
+							Elem:        statusCodeStatsElem(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func statusCodeStatsElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"status_code": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The response status.",
+			},
+			"requests": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of requests answered with this status.",
+			},
+		},
+	}
+}
+
+func dataSourceCacheflyServiceStatsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	from, _ := time.Parse(time.RFC3339, d.Get("from").(string))
+	to, _ := time.Parse(time.RFC3339, d.Get("to").(string))
+	if !to.After(from) {
+		return diag.Errorf("to (%s) must be after from (%s)", to.Format(time.RFC3339), from.Format(time.RFC3339))
+	}
+
+	endpoint := "/api/2.5/reports/account"
+	scope := "account"
+	if serviceID, ok := d.GetOk("service_id"); ok {
+		endpoint = fmt.Sprintf("/api/2.5/reports/services/%s", serviceID.(string))
+		scope = serviceID.(string)
+	}
+
+	queryParams := url.Values{}
+	queryParams.Set("from", from.UTC().Format(time.RFC3339))
+	queryParams.Set("to", to.UTC().Format(time.RFC3339))
+	queryParams.Set("granularity", d.Get("granularity").(string))
+
+	var stats ServiceStats
+	if err := fetchJSON(client, endpoint, queryParams, &stats); err != nil {
+		return diag.Errorf("failed to fetch statistics: %v", err)
+	}
+
+	series := make([]map[string]interface{}, 0, len(stats.Data))
+	for _, point := range stats.Data {
+		series = append(series, map[string]interface{}{
+			"timestamp":       point.Timestamp,
+			"requests":        int(point.Requests),
+			"bandwidth_bytes": int(point.Bytes),
+			"cache_hit_ratio": cacheHitRatio(point),
+			"status_codes":    flattenStatusCodeStats(point.StatusCodes),
+		})
+	}
+	sort.SliceStable(series, func(i, j int) bool {
+		return series[i]["timestamp"].(string) < series[j]["timestamp"].(string)
+	})
+
+	d.Set("requests", int(stats.Totals.Requests))
+	d.Set("bandwidth_bytes", int(stats.Totals.Bytes))
+	d.Set("cache_hit_ratio", cacheHitRatio(stats.Totals))
+	if err := d.Set("status_codes", flattenStatusCodeStats(stats.Totals.StatusCodes)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("series", series); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s/%s", scope, queryParams.Get("from"), queryParams.Get("to"), d.Get("granularity").(string)))
+	return nil
+}
+
+func cacheHitRatio(point StatsPoint) float64 {
+	if point.Requests == 0 {
+		return 0
+	}
+	return float64(point.Hits) / float64(point.Requests)
+}
+
+// flattenStatusCodeStats turns the status code counters into a list ordered by status.
+func flattenStatusCodeStats(statusCodes map[string]int64) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(statusCodes))
+	for code, requests := range statusCodes {
+		status, err := strconv.Atoi(code)
+		if err != nil {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"status_code": status,
+			"requests":    int(requests),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i]["status_code"].(int) < result[j]["status_code"].(int)
+	})
+	return result
+}
@@ -0,0 +1,60 @@
+package cachefly
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCacheflyServiceStatsDataSource(t *testing.T) {
+	srv := newTestServer(t)
+	service := srv.AddService(fakeapi.Service{Name: "Stats", UniqueName: "stats"})
+	srv.AddService(fakeapi.Service{Name: "Other", UniqueName: "other"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + fmt.Sprintf(`
+data "cachefly_service_stats" "service" {
+  service_id = %q
+  from       = "2024-01-01T00:00:00Z"
+  to         = "2024-01-03T00:00:00Z"
+}
+
+data "cachefly_service_stats" "account" {
+  from        = "2024-01-01T00:00:00Z"
+  to          = "2024-01-01T06:00:00Z"
+  granularity = "HOUR"
+}
+`, service.ID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cachefly_service_stats.service", "id", service.ID+"/2024-01-01T00:00:00Z/2024-01-03T00:00:00Z/DAY"),
+					resource.TestCheckResourceAttr("data.cachefly_service_stats.service", "requests", "2000"),
+					resource.TestCheckResourceAttr("data.cachefly_service_stats.service", "bandwidth_bytes", "2097152"),
+					resource.TestCheckResourceAttr("data.cachefly_service_stats.service", "cache_hit_ratio", "0.9"),
+					resource.TestCheckResourceAttr("data.cachefly_service_stats.service", "status_codes.#", "2"),
+					resource.TestCheckResourceAttr("data.cachefly_service_stats.service", "status_codes.1.status_code", "404"),
+					resource.TestCheckResourceAttr("data.cachefly_service_stats.service", "status_codes.1.requests", "100"),
+					resource.TestCheckResourceAttr("data.cachefly_service_stats.service", "series.#", "2"),
+					resource.TestCheckResourceAttr("data.cachefly_service_stats.service", "series.1.timestamp", "2024-01-02T00:00:00Z"),
+					resource.TestCheckResourceAttr("data.cachefly_service_stats.account", "series.#", "6"),
+					resource.TestCheckResourceAttr("data.cachefly_service_stats.account", "requests", "12000"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(srv) + `
+data "cachefly_service_stats" "missing" {
+  service_id = "missing"
+  from       = "2024-01-01T00:00:00Z"
+  to         = "2024-01-02T00:00:00Z"
+}
+`,
+				ExpectError: regexp.MustCompile(`failed to fetch statistics`),
+			},
+		},
+	})
+}
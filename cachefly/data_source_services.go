@@ -2,10 +2,6 @@ package cachefly
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"strconv"
 
@@ -23,16 +19,6 @@ type Service struct {
 	Status            string `json:"status"`
 }
 
-// ServicesResponse represents the structure of the API response for services.
-type ServicesResponse struct {
-	Meta struct {
-		Limit  int `json:"limit"`
-		Offset int `json:"offset"`
-		Count  int `json:"count"`
-	} `json:"meta"`
-	Data []Service `json:"data"`
-}
-
 // dataSourceCacheflyServices defines the schema for the data source.
 func dataSourceCacheflyServices() *schema.Resource {
 	return &schema.Resource{
@@ -114,27 +100,13 @@ func dataSourceCacheflyServicesRead(ctx context.Context, d *schema.ResourceData,
 	queryParams.Set("limit", strconv.Itoa(d.Get("limit").(int)))
 	queryParams.Set("offset", strconv.Itoa(d.Get("offset").(int)))
 
-	requestURL := fmt.Sprintf("%s/api/2.5/services?%s", client.APIURL, queryParams.Encode())
-
-	resp, err := makeRequestWithRetry(client, "GET", requestURL, nil)
-	if err != nil {
-		return diag.Errorf("failed to fetch services after retries: %v", err)
-	}
-	defer resp.Body.Close()
-
-	// Проверяем статус ответа
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return diag.Errorf("API returned non-200 status: %d. Response: %s", resp.StatusCode, string(body))
-	}
-
-	var response ServicesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return diag.Errorf("failed to decode services response: %v", err)
+	var data []Service
+	if _, err := fetchPage(client, "/api/2.5/services", queryParams, &data); err != nil {
+		return diag.Errorf("failed to fetch services: %v", err)
 	}
 
-	services := make([]map[string]interface{}, len(response.Data))
-	for i, service := range response.Data {
+	services := make([]map[string]interface{}, len(data))
+	for i, service := range data {
 		services[i] = map[string]interface{}{
 			"id":                 service.ID,
 			"name":               service.Name,
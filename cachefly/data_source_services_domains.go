@@ -15,10 +15,10 @@ import (
 
 // Domain represents a single domain object returned by the API.
 type Domain struct {
-	ID             string `json:"_id"`
-	Name           string `json:"name"`
-	Description    string `json:"description"`
-	ValidationMode string `json:"validationMode"`
+	ID               string `json:"_id"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	ValidationMode   string `json:"validationMode"`
 	ValidationStatus string `json:"validationStatus"`
 }
 
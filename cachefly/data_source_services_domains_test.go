@@ -0,0 +1,32 @@
+package cachefly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceCacheflyServiceDomainsRead(t *testing.T) {
+	client := newReplayClient(t, "service_domains")
+	d := schema.TestResourceDataRaw(t, dataSourceCacheflyServiceDomains().Schema, map[string]interface{}{
+		"service_id": "5e1f7c2a9d3b4a0012c0ffee",
+		"search":     "example",
+	})
+
+	if diags := dataSourceCacheflyServiceDomainsRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	assertResourceData(t, d, map[string]string{
+		"id":                          "5e1f7c2a9d3b4a0012c0ffee",
+		"domains.#":                   "2",
+		"domains.0.name":              "www.example.com",
+		"domains.0.description":       "Primary hostname",
+		"domains.0.validation_mode":   "HTTP",
+		"domains.0.validation_status": "VALIDATED",
+		"domains.1.name":              "static.example.com",
+		"domains.1.description":       "",
+		"domains.1.validation_status": "PENDING",
+	})
+}
@@ -0,0 +1,47 @@
+package cachefly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceCacheflyServicesRead(t *testing.T) {
+	client := newReplayClient(t, "services")
+	d := schema.TestResourceDataRaw(t, dataSourceCacheflyServices().Schema, map[string]interface{}{
+		"status": "ACTIVE",
+	})
+
+	if diags := dataSourceCacheflyServicesRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := map[string]string{
+		"services.#":                    "2",
+		"services.0.id":                 "5e1f7c2a9d3b4a0012c0ffee",
+		"services.0.name":               "Marketing site",
+		"services.0.unique_name":        "marketingsite",
+		"services.0.auto_ssl":           "true",
+		"services.0.configuration_mode": "API_RULES_AND_OPTIONS",
+		"services.0.status":             "ACTIVE",
+		"services.1.unique_name":        "downloads",
+		"services.1.auto_ssl":           "false",
+		"services.1.configuration_mode": "MIGRATED",
+	}
+	assertResourceData(t, d, expected)
+}
+
+// assertResourceData compares flatmapped attribute values of d with the expected ones.
+func assertResourceData(t *testing.T, d *schema.ResourceData, expected map[string]string) {
+	t.Helper()
+	state := d.State()
+	if state == nil {
+		t.Fatalf("no state was set")
+	}
+	for key, want := range expected {
+		if got := state.Attributes[key]; got != want {
+			t.Errorf("%s: expected %q, got %q", key, want, got)
+		}
+	}
+}
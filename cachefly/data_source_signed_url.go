@@ -0,0 +1,154 @@
+package cachefly
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourceCacheflySignedURL signs a URL locally with a token authentication secret. With ttl
+// the expiry is relative to the time of the read, so the URL changes on every plan.
+func dataSourceCacheflySignedURL() *schema.Resource {
+	return &schema.Resource{
+		Description: "Signs a URL locally with a token authentication secret. With ttl, or when neither ttl nor expires_at is set, " +
+			"the expiry is computed from the current time on every read, so the signed URL changes on every plan and anything " +
+			"using it is updated on every apply. Set expires_at instead for a signed URL that stays the same between plans.",
+		ReadContext: dataSourceCacheflySignedURLRead,
+		Schema: map[string]*schema.Schema{
+			"url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "The URL to sign.",
+			},
+			"secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The token authentication secret, usually the secret of a cachefly_service_token_auth resource.",
+			},
+			"algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "SHA256",
+				ValidateFunc: validation.StringInSlice(signingAlgorithms, false),
+				Description:  "The HMAC hash algorithm used for the token. Possible values: MD5, SHA1, SHA256.",
+			},
+			"expiry_parameter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "expires",
+				Description: "The name of the query parameter carrying the expiry timestamp.",
+			},
+			"token_parameter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "token",
+				Description: "The name of the query parameter carrying the token.",
+			},
+			"expires_at": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.IsRFC3339Time,
+				ConflictsWith: []string{"ttl"},
+				Description:   "The RFC3339 time the signed URL expires. Use it instead of ttl for a signed URL that does not change between plans. Conflicts with ttl.",
+			},
+			"ttl": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ValidateFunc:  validation.IntAtLeast(1),
+				ConflictsWith: []string{"expires_at"},
+				Description:   "The number of seconds the signed URL stays valid, counted from every read, so the signed URL changes on every plan. Defaults to 3600 when expires_at is not set.",
+			},
+			"expires": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The expiry of the signed URL as a Unix timestamp.",
+			},
+			"signed_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The signed URL.",
+			},
+		},
+	}
+}
+
+func dataSourceCacheflySignedURLRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	expires := time.Now().Add(3600 * time.Second)
+	if v, ok := d.GetOk("expires_at"); ok {
+		expires, _ = time.Parse(time.RFC3339, v.(string))
+	} else if v, ok := d.GetOk("ttl"); ok {
+		expires = time.Now().Add(time.Duration(v.(int)) * time.Second)
+	}
+
+	signed, err := signURL(
+		d.Get("url").(string),
+		d.Get("secret").(string),
+		d.Get("algorithm").(string),
+		d.Get("expiry_parameter").(string),
+		d.Get("token_parameter").(string),
+		expires.Unix(),
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The signed URL carries a valid token, only its hash is used as the ID.
+	sum := sha256.Sum256([]byte(signed))
+	d.SetId(hex.EncodeToString(sum[:]))
+	d.Set("expires", expires.Unix())
+	d.Set("signed_url", signed)
+
+	return nil
+}
+
+// signURL adds the expiry and token query parameters expected by CacheFly token authentication.
+// The token is the hex encoded HMAC of the URL path followed by the expiry timestamp.
+func signURL(rawURL, secret, algorithm, expiryParameter, tokenParameter string, expires int64) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+
+	var newHash func() hash.Hash
+	switch algorithm {
+	case "MD5":
+		newHash = md5.New
+	case "SHA1":
+		newHash = sha1.New
+	case "SHA256":
+		newHash = sha256.New
+	default:
+		return "", fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	expiresValue := strconv.FormatInt(expires, 10)
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write([]byte(path + expiresValue))
+
+	query := u.Query()
+	query.Set(expiryParameter, expiresValue)
+	query.Set(tokenParameter, hex.EncodeToString(mac.Sum(nil)))
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
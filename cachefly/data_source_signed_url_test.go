@@ -0,0 +1,61 @@
+package cachefly
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceCacheflySignedURLRead(t *testing.T) {
+	resource := dataSourceCacheflySignedURL()
+	if !resource.Schema["signed_url"].Sensitive {
+		t.Fatal("expected signed_url to be sensitive")
+	}
+
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"url":        "https://cdn.example.com/video.mp4",
+		"secret":     "s3cret",
+		"expires_at": "2030-01-01T00:00:00Z",
+	})
+	if diags := dataSourceCacheflySignedURLRead(context.Background(), d, nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	signed := d.Get("signed_url").(string)
+	if !strings.HasPrefix(signed, "https://cdn.example.com/video.mp4?") {
+		t.Fatalf("unexpected signed URL %q", signed)
+	}
+	sum := sha256.Sum256([]byte(signed))
+	if d.Id() != hex.EncodeToString(sum[:]) {
+		t.Fatalf("expected the ID to be the hash of the signed URL, got %q", d.Id())
+	}
+}
+
+// Only expires_at gives a signed URL that stays the same between plans.
+func TestDataSourceCacheflySignedURLReadStableWithExpiresAt(t *testing.T) {
+	read := func() string {
+		d := schema.TestResourceDataRaw(t, dataSourceCacheflySignedURL().Schema, map[string]interface{}{
+			"url":        "https://cdn.example.com/video.mp4",
+			"secret":     "s3cret",
+			"expires_at": "2030-01-01T00:00:00+01:00",
+		})
+		if diags := dataSourceCacheflySignedURLRead(context.Background(), d, nil); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if expires := d.Get("expires").(int); expires != 1893452400 {
+			t.Fatalf("expected expires to be the configured expires_at, got %d", expires)
+		}
+		return d.Get("signed_url").(string)
+	}
+
+	first := read()
+	time.Sleep(1100 * time.Millisecond)
+	if second := read(); second != first {
+		t.Fatalf("expected the same signed URL on every read, got %q and %q", first, second)
+	}
+}
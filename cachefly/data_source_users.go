@@ -0,0 +1,109 @@
+package cachefly
+
+import (
+	"context"
+	"net/url"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceCacheflyUsers lists the portal users of the account.
+func dataSourceCacheflyUsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCacheflyUsersRead,
+		Schema: map[string]*schema.Schema{
+			"search": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Search term to filter users by name or email.",
+			},
+			"permission": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return users granted this permission.",
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "A list of users.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the user.",
+						},
+						"email": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The email address of the user.",
+						},
+						"full_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The full name of the user.",
+						},
+						"permissions": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The permissions granted to the user.",
+						},
+						"service_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The services the user is restricted to. Empty when the user can access every service.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the user.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCacheflyUsersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	queryParams := url.Values{}
+	if search, ok := d.GetOk("search"); ok {
+		queryParams.Set("search", search.(string))
+	}
+
+	data, err := fetchAllPages[User](client, "/api/2.5/users", queryParams)
+	if err != nil {
+		return diag.Errorf("failed to fetch users: %v", err)
+	}
+
+	permission, filterPermission := d.GetOk("permission")
+
+	users := make([]map[string]interface{}, 0, len(data))
+	for _, user := range data {
+		if filterPermission && !slices.Contains(user.Permissions, permission.(string)) {
+			continue
+		}
+
+		users = append(users, map[string]interface{}{
+			"id":          user.ID,
+			"email":       user.Email,
+			"full_name":   user.FullName,
+			"permissions": user.Permissions,
+			"service_ids": user.Services,
+			"status":      user.Status,
+		})
+	}
+
+	if err := d.Set("users", users); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("cachefly_users")
+	return nil
+}
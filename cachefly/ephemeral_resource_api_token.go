@@ -0,0 +1,191 @@
+package cachefly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultEphemeralTokenTTL is how long an ephemeral token stays valid when ttl is not set.
+const defaultEphemeralTokenTTL = time.Hour
+
+// apiTokenEphemeralResource mints a short-lived API token for the duration of a Terraform run
+// and revokes it when Terraform closes the resource. The token never reaches the state.
+type apiTokenEphemeralResource struct {
+	client *CacheFlyClient
+}
+
+type apiTokenEphemeralResourceModel struct {
+	Name      types.String `tfsdk:"name"`
+	Scopes    types.Set    `tfsdk:"scopes"`
+	TTL       types.String `tfsdk:"ttl"`
+	ID        types.String `tfsdk:"id"`
+	Token     types.String `tfsdk:"token"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+// apiTokenPrivateKey is the private data key holding the ID of the token to revoke on close.
+const apiTokenPrivateKey = "token_id"
+
+var (
+	_ ephemeral.EphemeralResourceWithConfigure = &apiTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &apiTokenEphemeralResource{}
+)
+
+func newAPITokenEphemeralResource() ephemeral.EphemeralResource {
+	return &apiTokenEphemeralResource{}
+}
+
+func (r *apiTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_token"
+}
+
+func (r *apiTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates a short-lived API token when Terraform opens the resource and revokes it when Terraform closes it. " +
+			"The token is never written to the plan or the state. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "A name describing what the token is used for.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 64),
+				},
+			},
+			"scopes": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "The permissions granted to the token, e.g. SERVICES_READ, PURGE.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(permissionRegexp, "must be an uppercase permission name, e.g. SERVICES_READ")),
+				},
+			},
+			"ttl": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long the token stays valid as a Go duration, e.g. 15m. Defaults to 1h. The token also expires on its own if Terraform cannot revoke it.",
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the token.",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The token value.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The RFC3339 time the token expires.",
+			},
+		},
+	}
+}
+
+func (r *apiTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CacheFlyClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *CacheFlyClient, got %T.", req.ProviderData))
+		return
+	}
+	r.client = client
+}
+
+func (r *apiTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "The provider needs a token, set token in the provider configuration or the CACHEFLY_TOKEN environment variable.")
+		return
+	}
+
+	var config apiTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ttl := defaultEphemeralTokenTTL
+	if !config.TTL.IsNull() {
+		ttl, _ = time.ParseDuration(config.TTL.ValueString())
+	}
+
+	var scopes []string
+	resp.Diagnostics.Append(config.Scopes.ElementsAs(ctx, &scopes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := createAPIToken(r.client, APIToken{
+		Name:      config.Name.ValueString(),
+		Scopes:    scopes,
+		ExpiresAt: r.client.timeSource().Now().Add(ttl).UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create API token", err.Error())
+		return
+	}
+
+	privateData, _ := json.Marshal(created.ID)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, apiTokenPrivateKey, privateData)...)
+
+	config.ID = types.StringValue(created.ID)
+	config.Token = types.StringValue(created.Token)
+	config.ExpiresAt = types.StringValue(created.ExpiresAt)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &config)...)
+}
+
+func (r *apiTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	privateData, diags := req.Private.GetKey(ctx, apiTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || privateData == nil {
+		return
+	}
+
+	var tokenID string
+	if err := json.Unmarshal(privateData, &tokenID); err != nil {
+		resp.Diagnostics.AddError("Failed to read API token ID", err.Error())
+		return
+	}
+
+	if err := deleteAPIToken(r.client, tokenID); err != nil {
+		resp.Diagnostics.AddError("Failed to revoke API token",
+			fmt.Sprintf("Token %s could not be revoked and stays valid until it expires: %v", tokenID, err))
+	}
+}
+
+// durationValidator checks that a string is a positive Go duration.
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration, e.g. 15m or 2h"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration",
+			fmt.Sprintf("%s, got %q.", v.Description(ctx), req.ConfigValue.ValueString()))
+	}
+}
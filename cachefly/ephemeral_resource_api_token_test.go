@@ -0,0 +1,85 @@
+package cachefly
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCacheflyAPITokenEphemeral(t *testing.T) {
+	testAccPreCheckTerraformVersion(t, "1.10.0")
+	srv := newTestServer(t)
+
+	// The scoped provider authenticates with the ephemeral token, so the data source only
+	// reads successfully when the token was minted and handed over.
+	config := testAccFunctionConfig(srv) + fmt.Sprintf(`
+ephemeral "cachefly_api_token" "ci" {
+  name   = "ci"
+  scopes = ["SERVICES_READ"]
+  ttl    = "15m"
+}
+
+provider "cachefly" {
+  alias   = "scoped"
+  api_url = %q
+  token   = ephemeral.cachefly_api_token.ci.token
+}
+
+data "cachefly_account" "scoped" {
+  provider = cachefly.scoped
+}
+`, srv.URL)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testAccCheckTokensRevoked(srv)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cachefly_account.scoped", "company_name", "Example Inc"),
+					func(s *terraform.State) error {
+						for name, rs := range s.RootModule().Resources {
+							for key, value := range rs.Primary.Attributes {
+								if strings.HasPrefix(value, "fake-api-token-") {
+									return fmt.Errorf("ephemeral token leaked into the state as %s.%s", name, key)
+								}
+							}
+						}
+						return testAccCheckTokensRevoked(srv)
+					},
+				),
+			},
+		},
+	})
+
+	var created, revoked int
+	for _, req := range srv.Requests() {
+		switch {
+		case req.Method == "POST" && req.Path == "/api/2.5/tokens":
+			created++
+			if !strings.Contains(req.Body, `"expiresAt":"`) {
+				t.Errorf("expected the token to be created with an expiry, got %s", req.Body)
+			}
+		case req.Method == "DELETE" && strings.HasPrefix(req.Path, "/api/2.5/tokens/"):
+			revoked++
+		}
+	}
+	if created == 0 || created != revoked {
+		t.Fatalf("expected every created token to be revoked, created %d and revoked %d", created, revoked)
+	}
+}
+
+// testAccCheckTokensRevoked checks that no API token is left behind on the fake API.
+func testAccCheckTokensRevoked(srv *fakeapi.Server) error {
+	if tokens := srv.Tokens(); len(tokens) > 0 {
+		return fmt.Errorf("%d API tokens were not revoked", len(tokens))
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package cachefly
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// defaultHostnameFunction computes the <unique_name>.cachefly.net hostname of a service.
+type defaultHostnameFunction struct{}
+
+func newDefaultHostnameFunction() function.Function {
+	return &defaultHostnameFunction{}
+}
+
+func (f *defaultHostnameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "default_hostname"
+}
+
+func (f *defaultHostnameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Returns the default hostname of a service",
+		Description: "Returns the <unique_name>.cachefly.net hostname CacheFly assigns to every service. Fails when the unique_name is not valid.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "unique_name",
+				Description: "The unique_name of the service.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *defaultHostnameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var uniqueName string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &uniqueName))
+	if resp.Error != nil {
+		return
+	}
+
+	if !isValidUniqueName(uniqueName) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf(
+			"unique_name must be lowercase, alphanumeric, and between 3-32 characters long. Found: %s", uniqueName))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, defaultHostname(uniqueName)))
+}
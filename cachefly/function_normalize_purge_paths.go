@@ -0,0 +1,47 @@
+package cachefly
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// normalizePurgePathsFunction normalizes purge paths the way cachefly_purge does.
+type normalizePurgePathsFunction struct{}
+
+func newNormalizePurgePathsFunction() function.Function {
+	return &normalizePurgePathsFunction{}
+}
+
+func (f *normalizePurgePathsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_purge_paths"
+}
+
+func (f *normalizePurgePathsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalizes a list of purge paths",
+		Description: "Trims, cleans, prefixes with '/' and de-duplicates purge paths and returns them sorted, as cachefly_purge " +
+			"sends them to the API. A full purge pattern (\"*\" or \"/*\") makes every other path redundant and is returned on its own.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "paths",
+				Description: "The paths to normalize.",
+				ElementType: types.StringType,
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *normalizePurgePathsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var paths []string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &paths))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, normalizePurgePaths(paths)))
+}
@@ -0,0 +1,81 @@
+package cachefly
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// signedURLFunction signs a URL for token authentication like the cachefly_signed_url data
+// source. Functions must be pure, so the expiry is an absolute time instead of a TTL.
+type signedURLFunction struct{}
+
+func newSignedURLFunction() function.Function {
+	return &signedURLFunction{}
+}
+
+func (f *signedURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "signed_url"
+}
+
+func (f *signedURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Signs a CDN URL for token authentication",
+		Description: "Returns the URL with the expires and token query parameters expected by a service protected by " +
+			"cachefly_service_token_auth with the default parameter names. Use the cachefly_signed_url data source for " +
+			"custom parameter names or a relative TTL.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "url",
+				Description: "The URL to sign.",
+			},
+			function.StringParameter{
+				Name:        "secret",
+				Description: "The token authentication secret.",
+			},
+			function.StringParameter{
+				Name:        "algorithm",
+				Description: fmt.Sprintf("The HMAC hash algorithm used for the token. Possible values: %s.", strings.Join(signingAlgorithms, ", ")),
+			},
+			function.StringParameter{
+				Name:        "expires_at",
+				Description: "The RFC3339 time the signed URL expires, e.g. timeadd(plantimestamp(), \"1h\").",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *signedURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rawURL, secret, algorithm, expiresAt string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &rawURL, &secret, &algorithm, &expiresAt))
+	if resp.Error != nil {
+		return
+	}
+
+	if secret == "" {
+		resp.Error = function.NewArgumentFuncError(1, "secret must not be empty")
+		return
+	}
+	if !slices.Contains(signingAlgorithms, algorithm) {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("algorithm must be one of %s. Found: %s", strings.Join(signingAlgorithms, ", "), algorithm))
+		return
+	}
+	expires, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(3, fmt.Sprintf("expires_at must be an RFC3339 time: %s", err))
+		return
+	}
+
+	signed, err := signURL(rawURL, secret, algorithm, "expires", "token", expires.Unix())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, signed))
+}
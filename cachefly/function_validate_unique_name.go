@@ -0,0 +1,42 @@
+package cachefly
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// validateUniqueNameFunction exposes the unique_name rules of cachefly_service to modules.
+type validateUniqueNameFunction struct{}
+
+func newValidateUniqueNameFunction() function.Function {
+	return &validateUniqueNameFunction{}
+}
+
+func (f *validateUniqueNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_unique_name"
+}
+
+func (f *validateUniqueNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Checks a service unique_name",
+		Description: "Returns true when the name is a valid cachefly_service unique_name: lowercase, alphanumeric and between 3 and 32 characters long. Meant for variable validation blocks.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "unique_name",
+				Description: "The unique_name to check.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *validateUniqueNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var uniqueName string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &uniqueName))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, isValidUniqueName(uniqueName)))
+}
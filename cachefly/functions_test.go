@@ -0,0 +1,155 @@
+package cachefly
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// runFunction calls a provider function with the given arguments.
+func runFunction(f function.Function, result attr.Value, args ...attr.Value) (attr.Value, *function.FuncError) {
+	resp := &function.RunResponse{Result: function.NewResultData(result)}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(args)}, resp)
+	return resp.Result.Value(), resp.Error
+}
+
+func TestValidateUniqueNameFunction(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"myservice":             true,
+		"abc":                   true,
+		"ab":                    false,
+		"MyService":             false,
+		"my-svc":                false,
+		strings.Repeat("a", 33): false,
+	} {
+		got, err := runFunction(newValidateUniqueNameFunction(), types.BoolUnknown(), types.StringValue(name))
+		if err != nil {
+			t.Fatalf("%q: %s", name, err)
+		}
+		if !got.Equal(types.BoolValue(expected)) {
+			t.Errorf("%q: expected %t, got %s", name, expected, got)
+		}
+	}
+}
+
+func TestDefaultHostnameFunction(t *testing.T) {
+	got, err := runFunction(newDefaultHostnameFunction(), types.StringUnknown(), types.StringValue("myservice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(types.StringValue("myservice.cachefly.net")) {
+		t.Fatalf("unexpected hostname %s", got)
+	}
+
+	if _, err := runFunction(newDefaultHostnameFunction(), types.StringUnknown(), types.StringValue("My_Service")); err == nil {
+		t.Fatal("expected an error for an invalid unique_name")
+	}
+}
+
+func TestSignedURLFunction(t *testing.T) {
+	expected, err := signURL("https://cdn.example.com/video.mp4", "0123456789abcdef", "SHA256", "expires", "token", 1767225600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, funcErr := runFunction(newSignedURLFunction(), types.StringUnknown(),
+		types.StringValue("https://cdn.example.com/video.mp4"),
+		types.StringValue("0123456789abcdef"),
+		types.StringValue("SHA256"),
+		types.StringValue("2026-01-01T00:00:00Z"),
+	)
+	if funcErr != nil {
+		t.Fatal(funcErr)
+	}
+	if !got.Equal(types.StringValue(expected)) {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+
+	_, funcErr = runFunction(newSignedURLFunction(), types.StringUnknown(),
+		types.StringValue("https://cdn.example.com/video.mp4"),
+		types.StringValue("0123456789abcdef"),
+		types.StringValue("CRC32"),
+		types.StringValue("2026-01-01T00:00:00Z"),
+	)
+	if funcErr == nil || funcErr.FunctionArgument == nil || *funcErr.FunctionArgument != 2 {
+		t.Fatalf("expected an error for the algorithm argument, got %v", funcErr)
+	}
+}
+
+func TestNormalizePurgePathsFunction(t *testing.T) {
+	paths, _ := types.ListValueFrom(context.Background(), types.StringType, []string{"b/", " /a//x ", "/b/"})
+	got, err := runFunction(newNormalizePurgePathsFunction(), types.ListUnknown(types.StringType), paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, _ := types.ListValueFrom(context.Background(), types.StringType, []string{"/a/x", "/b/"})
+	if !got.Equal(expected) {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestAccProviderFunctions(t *testing.T) {
+	testAccPreCheckTerraformVersion(t, "1.8.0")
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFunctionConfig(srv) + `
+output "valid" {
+  value = provider::cachefly::validate_unique_name("myservice")
+}
+
+output "hostname" {
+  value = provider::cachefly::default_hostname("myservice")
+}
+
+output "paths" {
+  value = join(",", provider::cachefly::normalize_purge_paths(["/b", "*", "/a"]))
+}
+
+output "signed" {
+  value = provider::cachefly::signed_url("https://cdn.example.com/a.mp4", "0123456789abcdef", "SHA256", "2026-01-01T00:00:00Z")
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("valid", "true"),
+					resource.TestCheckOutput("hostname", "myservice.cachefly.net"),
+					resource.TestCheckOutput("paths", "/*"),
+					resource.TestMatchOutput("signed", regexp.MustCompile(`^https://cdn\.example\.com/a\.mp4\?expires=1767225600&token=[0-9a-f]{64}$`)),
+				),
+			},
+			{
+				Config: testAccFunctionConfig(srv) + `
+output "hostname" {
+  value = provider::cachefly::default_hostname("Not-Valid")
+}
+`,
+				ExpectError: regexp.MustCompile(`unique_name must be lowercase`),
+			},
+		},
+	})
+}
+
+// testAccFunctionConfig declares the provider in required_providers, which Terraform needs to
+// resolve provider:: function calls. The test framework serves it under the hashicorp namespace.
+func testAccFunctionConfig(srv *fakeapi.Server) string {
+	return `
+terraform {
+  required_providers {
+    cachefly = {
+      source = "hashicorp/cachefly"
+    }
+  }
+}
+` + testAccProviderConfig(srv)
+}
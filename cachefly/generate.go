@@ -0,0 +1,320 @@
+package cachefly
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// GenerateOptions selects the services GenerateConfig writes configuration for.
+type GenerateOptions struct {
+	// Status keeps only services with this status, e.g. ACTIVE. Empty keeps every service.
+	Status string
+	// NamePrefix keeps only services whose unique_name starts with the prefix.
+	NamePrefix string
+}
+
+// GenerateConfig writes Terraform configuration for the services of the account to dir: one
+// file per service with a cachefly_service resource and the import block adopting it, a
+// providers.tf declaring the provider, and an inventory of the origins of the account. The
+// services are read the same way `terraform import` reads them, so the first plan after
+// importing shows no changes. Object storage credentials cannot be read back; they are taken
+// from sensitive variables declared next to the service, and when the API does not return
+// them the first apply sends them once. It returns the paths of the written files.
+func GenerateConfig(ctx context.Context, client *CacheFlyClient, opts GenerateOptions, dir string) ([]string, error) {
+	query := url.Values{}
+	query.Set("responseType", "full")
+	if opts.Status != "" {
+		query.Set("status", opts.Status)
+	}
+
+	services, err := fetchAllPages[ServiceResource](client, "/api/2.5/services", query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	var written []string
+	write := func(name string, f *hclwrite.File) error {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, f.Bytes(), 0o644); err != nil {
+			return err
+		}
+		written = append(written, path)
+		return nil
+	}
+
+	if err := write("providers.tf", generateProvidersFile()); err != nil {
+		return nil, err
+	}
+
+	labels := make(map[string]bool)
+	for _, service := range services {
+		if opts.Status != "" && !strings.EqualFold(service.Status, opts.Status) {
+			continue
+		}
+		if !strings.HasPrefix(service.UniqueName, opts.NamePrefix) {
+			continue
+		}
+
+		d := resourceCacheflyService().Data(nil)
+		d.SetId(service.ID)
+		if _, err := resourceCacheflyServiceImport(ctx, d, client); err != nil {
+			return nil, fmt.Errorf("failed to read service %s: %w", service.UniqueName, err)
+		}
+
+		label := resourceLabel(service.UniqueName)
+		for labels[label] {
+			label += "_"
+		}
+		labels[label] = true
+
+		if err := write(fmt.Sprintf("service_%s.tf", label), generateServiceFile(label, d.Id(), serviceConfig(d, label))); err != nil {
+			return nil, err
+		}
+	}
+
+	origins, err := fetchAllPages[Origin](client, "/api/2.5/origins", url.Values{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list origins: %w", err)
+	}
+	if len(origins) > 0 {
+		if err := write("origins.tf", generateOriginsFile(origins)); err != nil {
+			return nil, err
+		}
+	}
+
+	return written, nil
+}
+
+// generatedService is the part of a cachefly_service state that goes into its configuration.
+type generatedService struct {
+	attributes map[string]cty.Value
+	blocks     []generatedBlock
+	comments   []string
+	// variables are the sensitive input variables the configuration refers to.
+	variables []generatedVariable
+}
+
+type generatedBlock struct {
+	name       string
+	attributes map[string]cty.Value
+	// references are attributes set to an expression, such as a variable, instead of a value.
+	references map[string]hcl.Traversal
+}
+
+type generatedVariable struct {
+	name        string
+	description string
+}
+
+// serviceConfig converts an imported service state into configuration. Attributes equal to
+// their schema default are left out to keep the generated files short.
+func serviceConfig(d *schema.ResourceData, label string) generatedService {
+	config := generatedService{attributes: map[string]cty.Value{
+		"name":        cty.StringVal(d.Get("name").(string)),
+		"unique_name": cty.StringVal(d.Get("unique_name").(string)),
+	}}
+	if v := d.Get("description").(string); v != "" {
+		config.attributes["description"] = cty.StringVal(v)
+	}
+	if d.Get("auto_ssl").(bool) {
+		config.attributes["auto_ssl"] = cty.True
+	}
+	if d.Get("hostname_pass_through").(bool) {
+		config.attributes["hostname_pass_through"] = cty.True
+	}
+	if d.Get("cors").(bool) {
+		config.attributes["cors"] = cty.True
+	}
+	if d.Get("auto_redirect").(bool) {
+		config.attributes["auto_redirect"] = cty.True
+	}
+
+	if v := d.Get("reverse_proxy").([]interface{}); len(v) > 0 {
+		reverseProxy := v[0].(map[string]interface{})
+		attributes := map[string]cty.Value{
+			"hostname":             cty.StringVal(reverseProxy["hostname"].(string)),
+			"mode":                 cty.StringVal(reverseProxy["mode"].(string)),
+			"ttl":                  cty.NumberIntVal(int64(reverseProxy["ttl"].(int))),
+			"cache_by_query_param": cty.BoolVal(reverseProxy["cache_by_query_param"].(bool)),
+			"origin_scheme":        cty.StringVal(reverseProxy["origin_scheme"].(string)),
+			"use_robots_txt":       cty.BoolVal(reverseProxy["use_robots_txt"].(bool)),
+		}
+		block := generatedBlock{name: "reverse_proxy", attributes: attributes}
+		if reverseProxy["mode"] == "OBJECT_STORAGE" {
+			attributes["region"] = cty.StringVal(reverseProxy["region"].(string))
+
+			// The credentials are not returned by the API, the plan fails without them.
+			block.references = make(map[string]hcl.Traversal)
+			for _, credential := range []struct{ attribute, variable, description string }{
+				{"access_key_wo", label + "_access_key", "Object storage access key of the " + label + " service."},
+				{"secret_key_wo", label + "_secret_key", "Object storage secret key of the " + label + " service."},
+			} {
+				block.references[credential.attribute] = hcl.Traversal{
+					hcl.TraverseRoot{Name: "var"},
+					hcl.TraverseAttr{Name: credential.variable},
+				}
+				config.variables = append(config.variables, generatedVariable{name: credential.variable, description: credential.description})
+			}
+			config.comments = append(config.comments,
+				"The object storage credentials are not returned by the API and are read from the",
+				fmt.Sprintf("%s_access_key and %s_secret_key variables. Requires Terraform 1.11 or later.", label, label))
+		}
+		config.blocks = append(config.blocks, block)
+	}
+
+	if v := d.Get("error_ttl").([]interface{}); len(v) > 0 {
+		errorTTL := v[0].(map[string]interface{})
+		config.blocks = append(config.blocks, generatedBlock{name: "error_ttl", attributes: map[string]cty.Value{
+			"enabled": cty.BoolVal(errorTTL["enabled"].(bool)),
+			"value":   cty.NumberIntVal(int64(errorTTL["value"].(int))),
+		}})
+	}
+
+	if v := d.Get("shared_origin_shield").([]interface{}); len(v) > 0 {
+		sharedShield := v[0].(map[string]interface{})
+		attributes := map[string]cty.Value{"enabled": cty.BoolVal(sharedShield["enabled"].(bool))}
+		if value := sharedShield["value"].(string); value != "" {
+			attributes["value"] = cty.StringVal(value)
+		}
+		config.blocks = append(config.blocks, generatedBlock{name: "shared_origin_shield", attributes: attributes})
+	}
+
+	for _, item := range d.Get("domains").([]interface{}) {
+		domain := item.(map[string]interface{})
+		attributes := map[string]cty.Value{"name": cty.StringVal(domain["name"].(string))}
+		if v := domain["description"].(string); v != "" {
+			attributes["description"] = cty.StringVal(v)
+		}
+		if v := domain["validation_mode"].(string); v != "NONE" {
+			attributes["validation_mode"] = cty.StringVal(v)
+		}
+		if certificates := expandStringList(domain["certificates"]); len(certificates) > 0 {
+			values := make([]cty.Value, len(certificates))
+			for i, id := range certificates {
+				values[i] = cty.StringVal(id)
+			}
+			attributes["certificates"] = cty.ListVal(values)
+		}
+		config.blocks = append(config.blocks, generatedBlock{name: "domains", attributes: attributes})
+	}
+
+	return config
+}
+
+func generateServiceFile(label, serviceID string, config generatedService) *hclwrite.File {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+
+	importBlock := body.AppendNewBlock("import", nil).Body()
+	importBlock.SetAttributeTraversal("to", hcl.Traversal{hcl.TraverseRoot{Name: "cachefly_service"}, hcl.TraverseAttr{Name: label}})
+	importBlock.SetAttributeValue("id", cty.StringVal(serviceID))
+	body.AppendNewline()
+
+	for _, variable := range config.variables {
+		variableBlock := body.AppendNewBlock("variable", []string{variable.name}).Body()
+		variableBlock.SetAttributeValue("description", cty.StringVal(variable.description))
+		variableBlock.SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+		variableBlock.SetAttributeValue("sensitive", cty.True)
+		variableBlock.SetAttributeValue("ephemeral", cty.True)
+		body.AppendNewline()
+	}
+
+	for _, comment := range config.comments {
+		body.AppendUnstructuredTokens(hclwrite.Tokens{{Type: hclsyntax.TokenComment, Bytes: []byte("# " + comment + "\n")}})
+	}
+
+	resource := body.AppendNewBlock("resource", []string{"cachefly_service", label}).Body()
+	setAttributes(resource, config.attributes, "name", "unique_name", "description")
+	for _, block := range config.blocks {
+		resource.AppendNewline()
+		blockBody := resource.AppendNewBlock(block.name, nil).Body()
+		setAttributes(blockBody, block.attributes, "name", "hostname", "mode", "enabled")
+
+		references := make([]string, 0, len(block.references))
+		for name := range block.references {
+			references = append(references, name)
+		}
+		sort.Strings(references)
+		for _, name := range references {
+			blockBody.SetAttributeTraversal(name, block.references[name])
+		}
+	}
+
+	return f
+}
+
+func generateProvidersFile() *hclwrite.File {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+
+	requiredProviders := body.AppendNewBlock("terraform", nil).Body().AppendNewBlock("required_providers", nil).Body()
+	requiredProviders.SetAttributeValue("cachefly", cty.ObjectVal(map[string]cty.Value{
+		"source": cty.StringVal("alehyarmalovich/cachefly"),
+	}))
+	body.AppendNewline()
+
+	body.AppendUnstructuredTokens(hclwrite.Tokens{{Type: hclsyntax.TokenComment, Bytes: []byte("# The token is read from the CACHEFLY_TOKEN environment variable.\n")}})
+	body.AppendNewBlock("provider", []string{"cachefly"})
+
+	return f
+}
+
+// generateOriginsFile lists the origins of the account as comments. The provider has no
+// origin resource, so they are only recorded for reference.
+func generateOriginsFile(origins []Origin) *hclwrite.File {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+
+	lines := []string{"# Origins of the account. They are not managed by this provider and are listed for reference."}
+	for _, origin := range origins {
+		lines = append(lines, fmt.Sprintf("#   %s (%s)", origin.Name, origin.ID))
+	}
+	body.AppendUnstructuredTokens(hclwrite.Tokens{{Type: hclsyntax.TokenComment, Bytes: []byte(strings.Join(lines, "\n") + "\n")}})
+
+	return f
+}
+
+// setAttributes sets the attributes in the given leading order, followed by the rest sorted by name.
+func setAttributes(body *hclwrite.Body, attributes map[string]cty.Value, leading ...string) {
+	done := make(map[string]bool, len(attributes))
+	for _, name := range leading {
+		if value, ok := attributes[name]; ok {
+			body.SetAttributeValue(name, value)
+			done[name] = true
+		}
+	}
+
+	rest := make([]string, 0, len(attributes))
+	for name := range attributes {
+		if !done[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	for _, name := range rest {
+		body.SetAttributeValue(name, attributes[name])
+	}
+}
+
+// resourceLabel turns a unique_name into a valid Terraform resource name.
+func resourceLabel(uniqueName string) string {
+	if uniqueName == "" || (uniqueName[0] >= '0' && uniqueName[0] <= '9') {
+		return "service_" + uniqueName
+	}
+	return uniqueName
+}
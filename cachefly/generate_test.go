@@ -0,0 +1,201 @@
+package cachefly
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/internal/fakeapi"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// seedGenerateServer creates services covering every section GenerateConfig writes.
+func seedGenerateServer(t *testing.T, srv *fakeapi.Server) (*CacheFlyClient, fakeapi.Service) {
+	t.Helper()
+	client := NewCacheFlyClient(srv.URL, srv.Token)
+
+	alpha := srv.AddService(fakeapi.Service{Name: "Alpha", UniqueName: "teamalpha", Description: "main site", Status: "ACTIVE"})
+	store := srv.AddService(fakeapi.Service{Name: "Store", UniqueName: "teamstore", Status: "ACTIVE"})
+	srv.AddService(fakeapi.Service{Name: "Beta", UniqueName: "teambeta", Status: "DEACTIVATED"})
+	srv.AddService(fakeapi.Service{Name: "Other", UniqueName: "other", Status: "ACTIVE"})
+	srv.AddOrigin(fakeapi.Origin{Name: "assets", Type: "WEB", Hostname: "assets.example.com"})
+
+	err := updateServiceOptions(client, alpha.ID, map[string]interface{}{
+		"reverseProxy": map[string]interface{}{
+			"enabled":           true,
+			"hostname":          "origin.example.com",
+			"mode":              "WEB",
+			"ttl":               3600,
+			"cacheByQueryParam": true,
+			"originScheme":      "HTTPS",
+			"useRobotsTxt":      false,
+		},
+		"error_ttl":    map[string]interface{}{"enabled": true, "value": 60},
+		"sharedshield": map[string]interface{}{"enabled": true, "value": "IAD"},
+		"edgetoorigin": true,
+		"cors":         true,
+		"autoRedirect": true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := createServiceDomain(client, alpha.ID, "cdn.example.com", "primary", "MANUAL", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	err = updateServiceOptions(client, store.ID, map[string]interface{}{
+		"reverseProxy": map[string]interface{}{
+			"enabled":      true,
+			"hostname":     "bucket.s3.amazonaws.com",
+			"mode":         "OBJECT_STORAGE",
+			"ttl":          86400,
+			"originScheme": "HTTPS",
+			"region":       "us-east-1",
+			"accessKey":    "AKIAEXAMPLE",
+			"secretKey":    "secret-example",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, alpha
+}
+
+func TestGenerateConfig(t *testing.T) {
+	srv := newTestServer(t)
+	client, alpha := seedGenerateServer(t, srv)
+	dir := t.TempDir()
+
+	files, err := GenerateConfig(context.Background(), client, GenerateOptions{Status: "ACTIVE", NamePrefix: "team"}, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, file := range files {
+		names = append(names, filepath.Base(file))
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, diags := hclwrite.ParseConfig(data, file, hcl.InitialPos); diags.HasErrors() {
+			t.Fatalf("%s is not valid HCL: %s\n%s", file, diags, data)
+		}
+	}
+	if got := strings.Join(names, ","); got != "providers.tf,service_teamalpha.tf,service_teamstore.tf,origins.tf" {
+		t.Fatalf("unexpected files %s", got)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(dir, "service_teamalpha.tf"))
+	for _, expected := range []string{
+		`to = cachefly_service.teamalpha`,
+		`id = "` + alpha.ID + `"`,
+		`description           = "main site"`,
+		`hostname_pass_through = true`,
+		`cors                  = true`,
+		`auto_redirect         = true`,
+		`hostname             = "origin.example.com"`,
+		`cache_by_query_param = true`,
+		`validation_mode = "MANUAL"`,
+		`value   = "IAD"`,
+	} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("expected %q in the generated service:\n%s", expected, data)
+		}
+	}
+	if strings.Contains(string(data), "example.cachefly.net") || strings.Contains(string(data), "teamalpha.cachefly.net") {
+		t.Errorf("default domains must not be generated:\n%s", data)
+	}
+
+	store, _ := os.ReadFile(filepath.Join(dir, "service_teamstore.tf"))
+	for _, expected := range []string{
+		`variable "teamstore_access_key" {`,
+		`variable "teamstore_secret_key" {`,
+		`sensitive   = true`,
+		`mode                 = "OBJECT_STORAGE"`,
+		`region               = "us-east-1"`,
+		`access_key_wo        = var.teamstore_access_key`,
+		`secret_key_wo        = var.teamstore_secret_key`,
+	} {
+		if !strings.Contains(string(store), expected) {
+			t.Errorf("expected %q in the generated service:\n%s", expected, store)
+		}
+	}
+	if strings.Contains(string(store), "AKIAEXAMPLE") || strings.Contains(string(store), "secret-example") {
+		t.Errorf("credentials must not be written to the configuration:\n%s", store)
+	}
+
+	origins, _ := os.ReadFile(filepath.Join(dir, "origins.tf"))
+	if !strings.Contains(string(origins), "assets") {
+		t.Errorf("expected the origins inventory, got:\n%s", origins)
+	}
+}
+
+func TestResourceLabel(t *testing.T) {
+	if got := resourceLabel("1cdn"); got != "service_1cdn" {
+		t.Fatalf("expected a label that does not start with a digit, got %s", got)
+	}
+	if got := resourceLabel("cdn"); got != "cdn" {
+		t.Fatalf("expected the unique_name as label, got %s", got)
+	}
+}
+
+// TestAccGenerateConfig plans the generated import blocks. The plan-only step fails unless
+// the import comes without any change, which is the promise of the generator.
+func TestAccGenerateConfig(t *testing.T) {
+	srv := newTestServer(t)
+	client, _ := seedGenerateServer(t, srv)
+	dir := t.TempDir()
+
+	if _, err := GenerateConfig(context.Background(), client, GenerateOptions{Status: "ACTIVE", NamePrefix: "team"}, dir); err != nil {
+		t.Fatal(err)
+	}
+	generated, err := os.ReadFile(filepath.Join(dir, "service_teamalpha.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:   testAccProviderConfig(srv) + string(generated),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// TestAccGenerateConfig_objectStorage plans the generated configuration of an object storage
+// service, whose credentials come from the generated write-only variables.
+func TestAccGenerateConfig_objectStorage(t *testing.T) {
+	testAccPreCheckTerraformVersion(t, "1.11.0")
+	srv := newTestServer(t)
+	client, _ := seedGenerateServer(t, srv)
+	dir := t.TempDir()
+
+	if _, err := GenerateConfig(context.Background(), client, GenerateOptions{NamePrefix: "teamstore"}, dir); err != nil {
+		t.Fatal(err)
+	}
+	generated, err := os.ReadFile(filepath.Join(dir, "service_teamstore.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TF_VAR_teamstore_access_key", "AKIAEXAMPLE")
+	t.Setenv("TF_VAR_teamstore_secret_key", "secret-example")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:   testAccProviderConfig(srv) + string(generated),
+				PlanOnly: true,
+			},
+		},
+	})
+}
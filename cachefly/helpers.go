@@ -8,7 +8,11 @@ import (
 	"log"
 	"math"
 	"net/http"
+	"net/url"
 	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -44,16 +48,26 @@ func makeRequest(client *CacheFlyClient, method, url string, body interface{}) (
 	return resp, nil
 }
 
+// maxRetryAfter caps how long a Retry-After header can make a request wait.
+const maxRetryAfter = time.Minute
+
 // makeRequestWithRetry makes an HTTP request with retry logic for transient errors.
+// Transport errors, 5xx responses and 429 Too Many Requests are retried with exponential
+// backoff, or after the delay given by a Retry-After header when the API sends one.
 func makeRequestWithRetry(client *CacheFlyClient, method, url string, body interface{}) (*http.Response, error) {
 	const maxRetries = 5
 	const baseDelay = time.Second // Start with 1 second delay
 
+	clock := client.timeSource()
 	var lastErr error
 
 	for attempt := 0; attempt < maxRetries; attempt++ {
+		if attempt > 0 {
+			clock.Sleep(retryDelay(attempt, baseDelay, lastErr))
+		}
+
 		resp, err := makeRequest(client, method, url, body)
-		if err == nil && resp.StatusCode < 500 {
+		if err == nil && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
 			// If no error and status code < 500, return the response
 			return resp, nil
 		}
@@ -64,6 +78,11 @@ func makeRequestWithRetry(client *CacheFlyClient, method, url string, body inter
 			log.Printf("[WARN] Request failed with status %d: %s. Retrying attempt %d/%d...",
 				resp.StatusCode, string(body), attempt+1, maxRetries)
 			resp.Body.Close()
+			lastErr = &retryableStatusError{
+				StatusCode: resp.StatusCode,
+				Body:       string(body),
+				RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), clock.Now()),
+			}
 		}
 
 		// Log the error and retry
@@ -71,16 +90,147 @@ func makeRequestWithRetry(client *CacheFlyClient, method, url string, body inter
 			log.Printf("[WARN] Request error: %v. Retrying attempt %d/%d...", err, attempt+1, maxRetries)
 			lastErr = err
 		}
-
-		// Exponential backoff with jitter
-		delay := time.Duration(math.Pow(2, float64(attempt))) * baseDelay
-		delay += time.Duration(rand.Intn(100)) * time.Millisecond // Add jitter
-		time.Sleep(delay)
 	}
 
 	return nil, fmt.Errorf("request failed after %d attempts: %w", maxRetries, lastErr)
 }
 
+// retryableStatusError is a response that was retried because of its status code.
+type retryableStatusError struct {
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration
+}
+
+func (e *retryableStatusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// retryDelay returns how long to wait before the given attempt. A Retry-After header on the
+// previous response wins over exponential backoff with jitter.
+func retryDelay(attempt int, baseDelay time.Duration, lastErr error) time.Duration {
+	if statusErr, ok := lastErr.(*retryableStatusError); ok && statusErr.RetryAfter > 0 {
+		return statusErr.RetryAfter
+	}
+
+	// Exponential backoff with jitter
+	delay := time.Duration(math.Pow(2, float64(attempt-1))) * baseDelay
+	delay += time.Duration(rand.Intn(100)) * time.Millisecond // Add jitter
+	return delay
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+// It returns zero when the header is missing or invalid, and caps the delay at maxRetryAfter.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = date.Sub(now)
+	}
+
+	if delay < 0 {
+		return 0
+	}
+	if delay > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return delay
+}
+
+// fetchJSON GETs an endpoint that is not paginated and decodes the response into out.
+func fetchJSON(client *CacheFlyClient, endpoint string, query url.Values, out interface{}) error {
+	requestURL := fmt.Sprintf("%s%s?%s", client.APIURL, endpoint, query.Encode())
+
+	resp, err := makeRequestWithRetry(client, "GET", requestURL, nil)
+	if err != nil {
+		return fmt.Errorf("request failed after retries: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API returned non-200 status: %d. Response: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// pageMeta is the pagination metadata returned by the list endpoints.
+type pageMeta struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	Count  int `json:"count"`
+}
+
+// listPageSize is the page size used when walking every page of a list endpoint.
+const listPageSize = 100
+
+// fetchPage GETs a single page of a list endpoint and decodes its data into out.
+func fetchPage(client *CacheFlyClient, endpoint string, query url.Values, out interface{}) (pageMeta, error) {
+	requestURL := fmt.Sprintf("%s%s?%s", client.APIURL, endpoint, query.Encode())
+
+	resp, err := makeRequestWithRetry(client, "GET", requestURL, nil)
+	if err != nil {
+		return pageMeta{}, fmt.Errorf("request failed after retries: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return pageMeta{}, fmt.Errorf("API returned non-200 status: %d. Response: %s", resp.StatusCode, string(body))
+	}
+
+	var page struct {
+		Meta pageMeta        `json:"meta"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return pageMeta{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(page.Data) > 0 {
+		if err := json.Unmarshal(page.Data, out); err != nil {
+			return pageMeta{}, fmt.Errorf("failed to decode response data: %w", err)
+		}
+	}
+
+	return page.Meta, nil
+}
+
+// fetchAllPages walks every page of a list endpoint and returns the combined data.
+func fetchAllPages[T any](client *CacheFlyClient, endpoint string, query url.Values) ([]T, error) {
+	params := url.Values{}
+	for key, values := range query {
+		params[key] = values
+	}
+	params.Set("limit", strconv.Itoa(listPageSize))
+
+	var all []T
+	for offset := 0; ; {
+		params.Set("offset", strconv.Itoa(offset))
+
+		var items []T
+		meta, err := fetchPage(client, endpoint, params, &items)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		offset += len(items)
+
+		if len(items) == 0 || offset >= meta.Count {
+			return all, nil
+		}
+	}
+}
+
 // handleResponse validates HTTP response and returns an error if the status code is not 2xx.
 func handleResponse(resp *http.Response) error {
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
@@ -91,10 +241,23 @@ func handleResponse(resp *http.Response) error {
 	return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
 }
 
+// uniqueNameRegexp matches the characters allowed in a service unique_name.
+var uniqueNameRegexp = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// isValidUniqueName reports whether v is lowercase, alphanumeric and 3-32 characters long.
+func isValidUniqueName(v string) bool {
+	return len(v) >= 3 && len(v) <= 32 && uniqueNameRegexp.MatchString(v)
+}
+
+// defaultHostname returns the hostname CacheFly gives every service.
+func defaultHostname(uniqueName string) string {
+	return uniqueName + ".cachefly.net"
+}
+
 // Helper: validateUniqueName
 func validateUniqueName(val interface{}, key string) (warns []string, errs []error) {
 	v := val.(string)
-	if len(v) < 3 || len(v) > 32 || v != strings.ToLower(v) || !regexp.MustCompile(`^[a-z0-9]+$`).MatchString(v) {
+	if !isValidUniqueName(v) {
 		errs = append(errs, fmt.Errorf(
 			"%q must be lowercase, alphanumeric, and between 3-32 characters long. Found: %s",
 			key, v,
@@ -127,8 +290,8 @@ func getInt(data map[string]interface{}, key string, defaultValue int) int {
 
 // Helper to manage additional configurations
 func manageAdditionalConfigurations(client *CacheFlyClient, d *schema.ResourceData, serviceID string) error {
-	if domains, ok := d.GetOk("domains"); ok {
-		if err := manageServiceDomains(client, serviceID, domains.([]interface{})); err != nil {
+	if _, ok := d.GetOk("domains"); ok {
+		if err := manageServiceDomains(client, serviceID, desiredServiceDomains(d)); err != nil {
 			return err
 		}
 	}
@@ -150,6 +313,24 @@ func deactivateService(client *CacheFlyClient, serviceID string) error {
 	return nil
 }
 
+// Helper to permanently delete a service
+func deleteService(client *CacheFlyClient, serviceID string) error {
+	url := fmt.Sprintf("%s/api/2.5/services/%s", client.APIURL, serviceID)
+	resp, err := makeRequestWithRetry(client, "DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete service after retries: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err := handleResponse(resp); err != nil {
+		return fmt.Errorf("failed to delete service: %w", err)
+	}
+
+	return nil
+}
+
 // Helper to manage service domains
 func manageServiceDomains(client *CacheFlyClient, serviceID string, desiredDomains []interface{}) error {
 	existingDomains, err := fetchExistingDomains(client, serviceID)
@@ -169,17 +350,21 @@ func manageServiceDomains(client *CacheFlyClient, serviceID string, desiredDomai
 		name := domainMap["name"].(string)
 		description := domainMap["description"].(string)
 		validationMode := domainMap["validation_mode"].(string)
+		var certificates []string
+		if v := domainMap["certificates"]; v != nil {
+			certificates = expandStringList(v)
+		}
 
 		if existingDomain, exists := existingDomainMap[name]; exists {
-			// Update if the domain exists but differs in description or validation mode
-			if needsUpdate(existingDomain, description, validationMode) {
-				if err := updateServiceDomain(client, serviceID, existingDomain.ID, name, description, validationMode); err != nil {
+			// Update if the domain exists but differs in description, validation mode or certificates
+			if needsUpdate(existingDomain, description, validationMode, certificates) {
+				if err := updateServiceDomain(client, serviceID, existingDomain.ID, name, description, validationMode, certificates); err != nil {
 					return fmt.Errorf("failed to update domain '%s': %v", name, err)
 				}
 			}
 		} else {
 			// Create a new domain if it doesn't exist
-			if err := createServiceDomain(client, serviceID, name, description, validationMode); err != nil {
+			if err := createServiceDomain(client, serviceID, name, description, validationMode, certificates); err != nil {
 				return fmt.Errorf("failed to create domain '%s': %v", name, err)
 			}
 		}
@@ -217,13 +402,9 @@ func fetchExistingDomains(client *CacheFlyClient, serviceID string) ([]DomainRes
 }
 
 // Helper to update a domain for a service
-func updateServiceDomain(client *CacheFlyClient, serviceID, domainID, name, description, validationMode string) error {
+func updateServiceDomain(client *CacheFlyClient, serviceID, domainID, name, description, validationMode string, certificates []string) error {
 	url := fmt.Sprintf("%s/api/2.5/services/%s/domains/%s", client.APIURL, serviceID, domainID)
-	body := map[string]string{
-		"name":           name,
-		"description":    description,
-		"validationMode": validationMode,
-	}
+	body := domainRequestBody(name, description, validationMode, certificates)
 
 	resp, err := makeRequestWithRetry(client, "PUT", url, body)
 	if err != nil {
@@ -239,13 +420,9 @@ func updateServiceDomain(client *CacheFlyClient, serviceID, domainID, name, desc
 }
 
 // Helper to create a domain for a service
-func createServiceDomain(client *CacheFlyClient, serviceID, name, description, validationMode string) error {
+func createServiceDomain(client *CacheFlyClient, serviceID, name, description, validationMode string, certificates []string) error {
 	url := fmt.Sprintf("%s/api/2.5/services/%s/domains", client.APIURL, serviceID)
-	body := map[string]string{
-		"name":           name,
-		"description":    description,
-		"validationMode": validationMode,
-	}
+	body := domainRequestBody(name, description, validationMode, certificates)
 
 	resp, err := makeRequestWithRetry(client, "POST", url, body)
 	if err != nil {
@@ -260,6 +437,21 @@ func createServiceDomain(client *CacheFlyClient, serviceID, name, description, v
 	return nil
 }
 
+// domainRequestBody builds the create/update payload for a domain. Certificates are only
+// sent when configured, so associations made outside Terraform are left untouched, while an
+// empty non-nil list detaches every certificate.
+func domainRequestBody(name, description, validationMode string, certificates []string) map[string]interface{} {
+	body := map[string]interface{}{
+		"name":           name,
+		"description":    description,
+		"validationMode": validationMode,
+	}
+	if certificates != nil {
+		body["certificates"] = certificates
+	}
+	return body
+}
+
 func deleteUnusedDomains(client *CacheFlyClient, serviceID string, existingDomainMap map[string]DomainResource, processedDomains map[string]bool) error {
 	for name, existingDomain := range existingDomainMap {
 		// Skip if the domain has been processed or is a default CacheFly domain
@@ -295,8 +487,31 @@ func isDefaultDomain(name string) bool {
 	return strings.HasSuffix(name, ".cachefly.net")
 }
 
-func needsUpdate(existing DomainResource, description, validationMode string) bool {
-	return existing.Description != description || existing.ValidationMode != validationMode
+func needsUpdate(existing DomainResource, description, validationMode string, certificates []string) bool {
+	if existing.Description != description || existing.ValidationMode != validationMode {
+		return true
+	}
+	if certificates == nil {
+		return false
+	}
+
+	existingCertificates := append([]string(nil), existing.Certificates...)
+	desiredCertificates := append([]string(nil), certificates...)
+	sort.Strings(existingCertificates)
+	sort.Strings(desiredCertificates)
+	return !slices.Equal(existingCertificates, desiredCertificates)
+}
+
+// expandStringList converts a schema list value into a string slice.
+func expandStringList(v interface{}) []string {
+	list, _ := v.([]interface{})
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
 }
 
 func mapExistingDomains(domains []DomainResource) map[string]DomainResource {
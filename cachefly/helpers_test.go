@@ -0,0 +1,228 @@
+package cachefly
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/internal/fakeapi"
+)
+
+// fakeClock records the delays the client waits for instead of sleeping.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+}
+
+func (c *fakeClock) Sleeps() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]time.Duration(nil), c.sleeps...)
+}
+
+// newFaultClient returns a client for the fake API that never sleeps between retries.
+func newFaultClient(t *testing.T) (*fakeapi.Server, *CacheFlyClient, *fakeClock) {
+	t.Helper()
+	srv := newTestServer(t)
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	client := NewCacheFlyClient(srv.URL, srv.Token)
+	client.clock = clock
+	return srv, client, clock
+}
+
+func getAccount(t *testing.T, client *CacheFlyClient) (*http.Response, error) {
+	t.Helper()
+	resp, err := makeRequestWithRetry(client, "GET", client.APIURL+"/api/2.5/accounts/me", nil)
+	if resp != nil {
+		resp.Body.Close()
+	}
+	return resp, err
+}
+
+func TestRetryServerError(t *testing.T) {
+	srv, client, clock := newFaultClient(t)
+	srv.InjectFault(fakeapi.Fault{Path: "/api/2.5/accounts/me", Status: http.StatusBadGateway, Body: `{"message":"upstream down"}`, Times: 2})
+
+	var logs bytes.Buffer
+	previous := log.Writer()
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(previous) })
+
+	resp, err := getAccount(t, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 after retries, got %d", resp.StatusCode)
+	}
+
+	sleeps := clock.Sleeps()
+	if len(sleeps) != 2 {
+		t.Fatalf("expected 2 backoffs, got %v", sleeps)
+	}
+	if sleeps[0] < time.Second || sleeps[1] < 2*time.Second {
+		t.Fatalf("expected exponential backoff, got %v", sleeps)
+	}
+	if !strings.Contains(logs.String(), "upstream down") {
+		t.Fatalf("expected the failed response body to be logged, got %q", logs.String())
+	}
+}
+
+func TestRetryRateLimited(t *testing.T) {
+	tests := map[string]struct {
+		retryAfter string
+		expected   time.Duration
+	}{
+		"seconds":   {retryAfter: "7", expected: 7 * time.Second},
+		"http date": {retryAfter: "Mon, 01 Jan 2024 00:00:30 GMT", expected: 30 * time.Second},
+		"capped":    {retryAfter: "3600", expected: maxRetryAfter},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv, client, clock := newFaultClient(t)
+			srv.InjectFault(fakeapi.Fault{Kind: fakeapi.FaultRateLimit, Path: "/api/2.5/accounts/me", RetryAfter: tc.retryAfter, Times: 1})
+
+			resp, err := getAccount(t, client)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("expected 200 after the rate limit, got %d", resp.StatusCode)
+			}
+			if sleeps := clock.Sleeps(); len(sleeps) != 1 || sleeps[0] != tc.expected {
+				t.Fatalf("expected a single wait of %s, got %v", tc.expected, sleeps)
+			}
+		})
+	}
+}
+
+func TestRetryDroppedConnection(t *testing.T) {
+	srv, client, clock := newFaultClient(t)
+	srv.InjectFault(fakeapi.Fault{Kind: fakeapi.FaultDrop, Path: "/api/2.5/accounts/me", Times: 1})
+
+	if _, err := getAccount(t, client); err != nil {
+		t.Fatal(err)
+	}
+	if sleeps := clock.Sleeps(); len(sleeps) != 1 {
+		t.Fatalf("expected one retry, got %v", sleeps)
+	}
+}
+
+func TestRetrySlowResponse(t *testing.T) {
+	srv, client, clock := newFaultClient(t)
+	client.HTTP.Timeout = 20 * time.Millisecond
+	srv.InjectFault(fakeapi.Fault{Kind: fakeapi.FaultSlow, Path: "/api/2.5/accounts/me", Delay: time.Second, Times: 1})
+
+	if _, err := getAccount(t, client); err != nil {
+		t.Fatal(err)
+	}
+	if sleeps := clock.Sleeps(); len(sleeps) != 1 {
+		t.Fatalf("expected the timed out request to be retried once, got %v", sleeps)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	srv, client, clock := newFaultClient(t)
+	srv.InjectFault(fakeapi.Fault{Path: "/api/2.5/accounts/me", Status: http.StatusServiceUnavailable})
+
+	_, err := getAccount(t, client)
+	if err == nil {
+		t.Fatal("expected an error once retries are exhausted")
+	}
+	if !strings.Contains(err.Error(), "after 5 attempts") || !strings.Contains(err.Error(), "HTTP 503") {
+		t.Fatalf("expected the last status in the error, got %v", err)
+	}
+	if sleeps := clock.Sleeps(); len(sleeps) != 4 {
+		t.Fatalf("expected 4 waits between 5 attempts, got %v", sleeps)
+	}
+	if got := len(srv.Requests()); got != 5 {
+		t.Fatalf("expected 5 requests, got %d", got)
+	}
+}
+
+func TestRetryClientErrorNotRetried(t *testing.T) {
+	srv, client, clock := newFaultClient(t)
+	srv.InjectFault(fakeapi.Fault{Path: "/api/2.5/accounts/me", Status: http.StatusBadRequest, Times: 1})
+
+	resp, err := getAccount(t, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusBadRequest || len(clock.Sleeps()) != 0 {
+		t.Fatalf("expected the 400 to be returned without retrying, got %d after %v", resp.StatusCode, clock.Sleeps())
+	}
+}
+
+func TestMalformedJSONResponse(t *testing.T) {
+	srv, client, _ := newFaultClient(t)
+	service := srv.AddService(fakeapi.Service{Name: "Malformed", UniqueName: "malformed", Status: "ACTIVE"})
+	srv.InjectFault(fakeapi.Fault{Kind: fakeapi.FaultMalformedJSON, Path: "/api/2.5/services/*", Body: `{"_id":"` + service.ID, Times: 1})
+
+	if _, err := fetchServiceDetails(client, service.ID); err == nil {
+		t.Fatal("expected a decode error for a malformed response")
+	}
+
+	fetched, err := fetchServiceDetails(client, service.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fetched.UniqueName != "malformed" {
+		t.Fatalf("expected the service once the API recovers, got %+v", fetched)
+	}
+}
+
+func TestManageServiceDomainsPartialFailure(t *testing.T) {
+	srv, client, _ := newFaultClient(t)
+	service := srv.AddService(fakeapi.Service{Name: "Partial", UniqueName: "partial", Status: "ACTIVE"})
+	domains := []interface{}{
+		map[string]interface{}{"name": "a.example.com", "description": "", "validation_mode": "HTTP", "certificates": []interface{}{}},
+		map[string]interface{}{"name": "b.example.com", "description": "", "validation_mode": "HTTP", "certificates": []interface{}{}},
+	}
+
+	srv.InjectFault(fakeapi.Fault{Method: "POST", Path: "/api/2.5/services/*/domains", Status: http.StatusBadRequest, After: 1, Times: 1})
+
+	err := manageServiceDomains(client, service.ID, domains)
+	if err == nil || !strings.Contains(err.Error(), "b.example.com") {
+		t.Fatalf("expected the second domain to fail, got %v", err)
+	}
+	if names := domainNames(srv.Domains(service.ID)); names != "a.example.com" {
+		t.Fatalf("expected only the first domain to exist after the failure, got %q", names)
+	}
+
+	if err := manageServiceDomains(client, service.ID, domains); err != nil {
+		t.Fatalf("expected a rerun to converge, got %v", err)
+	}
+	if names := domainNames(srv.Domains(service.ID)); names != "a.example.com,b.example.com" {
+		t.Fatalf("expected both domains exactly once, got %q", names)
+	}
+}
+
+// domainNames returns the custom domain names of a fake service, without the default domain.
+func domainNames(domains []fakeapi.Domain) string {
+	var names []string
+	for _, domain := range domains {
+		if !strings.HasSuffix(domain.Name, ".cachefly.net") {
+			names = append(names, domain.Name)
+		}
+	}
+	return strings.Join(names, ",")
+}
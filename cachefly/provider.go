@@ -5,10 +5,28 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// ProviderServer serves the SDK provider and the framework provider as a single provider over
+// protocol version 5. Resources are routed to whichever of the two implements them.
+func ProviderServer(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	providers := []func() tfprotov5.ProviderServer{
+		Provider().GRPCProvider,
+		providerserver.NewProtocol5(NewFrameworkProvider()),
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, providers...)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
+
 // Provider initializes and returns the CacheFly Terraform provider.
 func Provider() *schema.Provider {
 	return &schema.Provider{
@@ -28,13 +46,25 @@ func Provider() *schema.Provider {
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"cachefly_service": resourceCacheflyService(),
+			"cachefly_service":                resourceCacheflyService(),
+			"cachefly_certificate":            resourceCacheflyCertificate(),
+			"cachefly_purge":                  resourceCacheflyPurge(),
+			"cachefly_service_rules":          resourceCacheflyServiceRules(),
+			"cachefly_service_token_auth":     resourceCacheflyServiceTokenAuth(),
+			"cachefly_service_access_control": resourceCacheflyServiceAccessControl(),
+			"cachefly_user":                   resourceCacheflyUser(),
+			"cachefly_api_token":              resourceCacheflyAPIToken(),
+			"cachefly_log_target":             resourceCacheflyLogTarget(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"cachefly_account":         dataSourceCacheflyAccount(),
 			"cachefly_services":        dataSourceCacheflyServices(),
 			"cachefly_origins":         dataSourceCacheflyOrigins(),
 			"cachefly_service_domains": dataSourceCacheflyServiceDomains(),
+			"cachefly_certificates":    dataSourceCacheflyCertificates(),
+			"cachefly_signed_url":      dataSourceCacheflySignedURL(),
+			"cachefly_users":           dataSourceCacheflyUsers(),
+			"cachefly_service_stats":   dataSourceCacheflyServiceStats(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -0,0 +1,114 @@
+package cachefly
+
+import (
+	"context"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// frameworkProvider is the terraform-plugin-framework half of the provider. It is muxed with
+// the SDK provider returned by Provider, see ProviderServer. New resources and data sources
+// are written against the framework and registered here; the SDK ones move over one at a
+// time as they are ported.
+type frameworkProvider struct{}
+
+var (
+	_ provider.ProviderWithFunctions          = &frameworkProvider{}
+	_ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+)
+
+// frameworkProviderModel is the provider configuration. Its schema must stay identical to
+// the schema of the SDK provider, which the mux server checks on startup.
+type frameworkProviderModel struct {
+	APIURL types.String `tfsdk:"api_url"`
+	Token  types.String `tfsdk:"token"`
+}
+
+// NewFrameworkProvider returns the terraform-plugin-framework provider.
+func NewFrameworkProvider() provider.Provider {
+	return &frameworkProvider{}
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "cachefly"
+}
+
+func (p *frameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"api_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "The base URL for the CacheFly API.",
+			},
+			"token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The API token for authenticating with the CacheFly API. Can also be set using the CACHEFLY_TOKEN environment variable.",
+			},
+		},
+	}
+}
+
+// Configure builds the same client as providerConfigure, so framework and SDK resources
+// share the provider configuration and environment defaults.
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config frameworkProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiURL := config.APIURL.ValueString()
+	if apiURL == "" {
+		apiURL = os.Getenv("CACHEFLY_API_URL")
+	}
+	if apiURL == "" {
+		apiURL = "https://api.cachefly.com"
+	}
+
+	token := config.Token.ValueString()
+	if token == "" {
+		token = os.Getenv("CACHEFLY_TOKEN")
+	}
+
+	// A missing token is already reported by the SDK provider, so it is not reported twice.
+	// Values that are unknown until apply leave the client unconfigured.
+	if config.APIURL.IsUnknown() || config.Token.IsUnknown() || token == "" {
+		return
+	}
+
+	client := NewCacheFlyClient(apiURL, token)
+	resp.DataSourceData = client
+	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newAPITokenEphemeralResource,
+	}
+}
+
+func (p *frameworkProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		newValidateUniqueNameFunction,
+		newDefaultHostnameFunction,
+		newSignedURLFunction,
+		newNormalizePurgePathsFunction,
+	}
+}
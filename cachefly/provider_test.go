@@ -0,0 +1,147 @@
+package cachefly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/internal/fakeapi"
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/internal/replay"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+// testAccProtoV5ProviderFactories serves the muxed provider in-process to the acceptance test
+// framework, the same way main serves it to Terraform.
+var testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"cachefly": func() (tfprotov5.ProviderServer, error) {
+		providerServer, err := ProviderServer(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		return providerServer(), nil
+	},
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestProviderServer(t *testing.T) {
+	providerServer, err := testAccProtoV5ProviderFactories["cachefly"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The mux server reports differing provider schemas of the SDK and framework providers
+	// as an error diagnostic here.
+	resp, err := providerServer.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	if _, ok := resp.ResourceSchemas["cachefly_service"]; !ok {
+		t.Errorf("expected the SDK resources to be served through the mux server")
+	}
+	if _, ok := resp.DataSourceSchemas["cachefly_services"]; !ok {
+		t.Errorf("expected the SDK data sources to be served through the mux server")
+	}
+}
+
+// testAccPreCheckTerraformVersion skips an acceptance test when the Terraform binary used by
+// the test framework is older than minimum.
+func testAccPreCheckTerraformVersion(t *testing.T, minimum string) {
+	t.Helper()
+	if os.Getenv("TF_ACC") == "" {
+		return
+	}
+
+	binary := os.Getenv("TF_ACC_TERRAFORM_PATH")
+	if binary == "" {
+		binary = "terraform"
+	}
+	out, err := exec.Command(binary, "version", "-json").Output()
+	if err != nil {
+		t.Fatalf("failed to run %s version: %v", binary, err)
+	}
+
+	var v struct {
+		Version string `json:"terraform_version"`
+	}
+	if err := json.Unmarshal(out, &v); err != nil {
+		t.Fatalf("failed to decode terraform version: %v", err)
+	}
+	if version.Must(version.NewVersion(v.Version)).LessThan(version.Must(version.NewVersion(minimum))) {
+		t.Skipf("requires Terraform %s or later, found %s", minimum, v.Version)
+	}
+}
+
+// newTestServer starts a fake CacheFly API that is closed when the test ends.
+func newTestServer(t *testing.T) *fakeapi.Server {
+	t.Helper()
+	srv := fakeapi.New()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// testAccProviderConfig points the provider at the fake API.
+func testAccProviderConfig(srv *fakeapi.Server) string {
+	return fmt.Sprintf(`
+provider "cachefly" {
+  api_url = %q
+  token   = %q
+}
+`, srv.URL, srv.Token)
+}
+
+// newReplayClient returns a client that answers from testdata/fixtures/<fixture>.json. With
+// CACHEFLY_RECORD set, the client talks to the real API using CACHEFLY_API_URL and
+// CACHEFLY_TOKEN instead and rewrites the fixture, scrubbed of credentials.
+func newReplayClient(t *testing.T, fixture string) *CacheFlyClient {
+	t.Helper()
+	path := filepath.Join("testdata", "fixtures", fixture+".json")
+
+	if os.Getenv("CACHEFLY_RECORD") != "" {
+		apiURL := os.Getenv("CACHEFLY_API_URL")
+		if apiURL == "" {
+			apiURL = "https://api.cachefly.com"
+		}
+		token := os.Getenv("CACHEFLY_TOKEN")
+		if token == "" {
+			t.Fatal("CACHEFLY_TOKEN must be set to record fixtures")
+		}
+		client := NewCacheFlyClient(apiURL, token)
+		recorder := replay.NewRecorder(path, nil)
+		client.HTTP.Transport = recorder
+		t.Cleanup(func() {
+			if err := recorder.Save(); err != nil {
+				t.Errorf("failed to save fixture %s: %v", path, err)
+			}
+		})
+		return client
+	}
+
+	replayer, err := replay.NewReplayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewCacheFlyClient("https://api.cachefly.com", "replay-token")
+	client.HTTP.Transport = replayer
+	t.Cleanup(func() {
+		if unused := replayer.Unused(); len(unused) > 0 {
+			t.Errorf("%d recorded interactions in %s were not replayed", len(unused), path)
+		}
+	})
+	return client
+}
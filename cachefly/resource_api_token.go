@@ -0,0 +1,221 @@
+package cachefly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// APIToken represents an API token of the account. Token is only returned when the token is created.
+type APIToken struct {
+	ID         string   `json:"_id,omitempty"`
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  string   `json:"expiresAt,omitempty"`
+	Token      string   `json:"token,omitempty"`
+	CreatedAt  string   `json:"createdAt,omitempty"`
+	LastUsedAt string   `json:"lastUsedAt,omitempty"`
+}
+
+func resourceCacheflyAPIToken() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCacheflyAPITokenCreate,
+		ReadContext:   resourceCacheflyAPITokenRead,
+		DeleteContext: resourceCacheflyAPITokenDelete,
+
+		CustomizeDiff: validateAPITokenExpiryDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 64),
+				Description:  "A name describing what the token is used for.",
+			},
+			"scopes": {
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(permissionRegexp, "must be an uppercase permission name, e.g. SERVICES_READ"),
+				},
+				Description: "The permissions granted to the token, e.g. SERVICES_MANAGE, SERVICES_READ, PURGE.",
+			},
+			"expires_at": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return sameInstant(old, new)
+				},
+				Description: "The RFC3339 time the token expires. The token does not expire when omitted. Changing it issues a new token.",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The token value. Only known when the token is created, it is kept in the state afterwards.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the token was created.",
+			},
+			"last_used_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The last time the token was used.",
+			},
+		},
+	}
+}
+
+func resourceCacheflyAPITokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	created, err := createAPIToken(client, APIToken{
+		Name:      d.Get("name").(string),
+		Scopes:    expandSortedSet(d.Get("scopes")),
+		ExpiresAt: d.Get("expires_at").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(created.ID)
+	d.Set("token", created.Token)
+
+	return resourceCacheflyAPITokenRead(ctx, d, meta)
+}
+
+func resourceCacheflyAPITokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	url := fmt.Sprintf("%s/api/2.5/tokens/%s", client.APIURL, d.Id())
+	resp, err := makeRequestWithRetry(client, "GET", url, nil)
+	if err != nil {
+		return diag.Errorf("failed to fetch API token after retries: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		d.SetId("")
+		return nil
+	} else if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return diag.Errorf("failed to fetch API token: HTTP %d. Response: %s", resp.StatusCode, string(body))
+	}
+
+	var token APIToken
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return diag.Errorf("failed to decode API token response: %v", err)
+	}
+
+	d.Set("name", token.Name)
+	d.Set("scopes", token.Scopes)
+	// The API formats expiry times its own way, e.g. in UTC with milliseconds. The configured
+	// value is kept while it names the same instant, so the token is not planned for replacement.
+	if !sameInstant(d.Get("expires_at").(string), token.ExpiresAt) {
+		d.Set("expires_at", token.ExpiresAt)
+	}
+	d.Set("created_at", token.CreatedAt)
+	d.Set("last_used_at", token.LastUsedAt)
+
+	return nil
+}
+
+func resourceCacheflyAPITokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	if err := deleteAPIToken(client, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func createAPIToken(client *CacheFlyClient, token APIToken) (*APIToken, error) {
+	resp, err := makeRequestWithRetry(client, "POST", fmt.Sprintf("%s/api/2.5/tokens", client.APIURL), token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API token after retries: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := handleResponse(resp); err != nil {
+		return nil, fmt.Errorf("failed to create API token: %w", err)
+	}
+
+	var created APIToken
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("failed to decode API token response: %w", err)
+	}
+
+	return &created, nil
+}
+
+// deleteAPIToken revokes an API token, a token that no longer exists is not an error.
+func deleteAPIToken(client *CacheFlyClient, tokenID string) error {
+	url := fmt.Sprintf("%s/api/2.5/tokens/%s", client.APIURL, tokenID)
+	resp, err := makeRequestWithRetry(client, "DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete API token after retries: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err := handleResponse(resp); err != nil {
+		return fmt.Errorf("failed to delete API token: %w", err)
+	}
+
+	return nil
+}
+
+// sameInstant reports whether two RFC3339 times name the same instant.
+func sameInstant(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ta, err := time.Parse(time.RFC3339, a)
+	if err != nil {
+		return false
+	}
+	tb, err := time.Parse(time.RFC3339, b)
+	if err != nil {
+		return false
+	}
+	return ta.Equal(tb)
+}
+
+// validateAPITokenExpiryDiff rejects new tokens that would already be expired.
+func validateAPITokenExpiryDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("expires_at") || !d.NewValueKnown("expires_at") {
+		return nil
+	}
+
+	value := d.Get("expires_at").(string)
+	if value == "" {
+		return nil
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	if !expiresAt.After(time.Now()) {
+		return fmt.Errorf("expires_at must be in the future, got %s", value)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package cachefly
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccAPITokenResource = "cachefly_api_token.test"
+
+func TestAccCacheflyAPIToken_basic(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			return testAccCheckTokensRevoked(srv)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAPITokenConfig(srv, `
+  name   = "ci"
+  scopes = ["PURGE"]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testAccAPITokenResource, "token"),
+					resource.TestCheckResourceAttrSet(testAccAPITokenResource, "created_at"),
+					testAccCheckFakeTokens(srv, "ci"),
+				),
+			},
+			{
+				// Tokens cannot be changed, a new one is issued and the old one revoked.
+				Config: testAccAPITokenConfig(srv, `
+  name       = "deploy"
+  scopes     = ["PURGE", "SERVICES_READ"]
+  expires_at = "2099-01-01T00:00:00Z"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccAPITokenResource, "expires_at", "2099-01-01T00:00:00Z"),
+					testAccCheckFakeTokens(srv, "deploy"),
+				),
+			},
+			{
+				// The same instant in another format must not issue a new token.
+				Config: testAccAPITokenConfig(srv, `
+  name       = "deploy"
+  scopes     = ["PURGE", "SERVICES_READ"]
+  expires_at = "2099-01-01T01:00:00+01:00"
+`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccAPITokenConfig(srv *fakeapi.Server, body string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "cachefly_api_token" "test" {
+%s}
+`, body)
+}
+
+// testAccCheckFakeTokens checks the names of the API tokens that have not been revoked.
+func testAccCheckFakeTokens(srv *fakeapi.Server, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var names []string
+		for _, token := range srv.Tokens() {
+			names = append(names, token.Name)
+		}
+		if fmt.Sprint(names) != fmt.Sprint(expected) {
+			return fmt.Errorf("expected tokens %v in the API, got %v", expected, names)
+		}
+		return nil
+	}
+}
+
+func TestSameInstant(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"2099-01-01T00:00:00Z", "2099-01-01T00:00:00Z", true},
+		{"2099-01-01T00:00:00Z", "2099-01-01T00:00:00.000Z", true},
+		{"2099-01-01T01:00:00+01:00", "2099-01-01T00:00:00.000Z", true},
+		{"2099-01-01T00:00:00Z", "2099-01-01T00:00:01Z", false},
+		{"", "2099-01-01T00:00:00Z", false},
+		{"", "", true},
+		{"tomorrow", "2099-01-01T00:00:00Z", false},
+	}
+
+	for _, tc := range tests {
+		if got := sameInstant(tc.a, tc.b); got != tc.expected {
+			t.Errorf("sameInstant(%q, %q) = %t, expected %t", tc.a, tc.b, got, tc.expected)
+		}
+	}
+}
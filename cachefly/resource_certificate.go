@@ -0,0 +1,243 @@
+package cachefly
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Certificate represents a TLS certificate returned by the API.
+type Certificate struct {
+	ID                string   `json:"_id,omitempty"`
+	Certificate       string   `json:"certificate,omitempty"`
+	CertificateKey    string   `json:"certificateKey,omitempty"`
+	SubjectCommonName string   `json:"subjectCommonName,omitempty"`
+	SubjectNames      []string `json:"subjectNames,omitempty"`
+	Issuer            string   `json:"issuer,omitempty"`
+	NotBefore         string   `json:"notBefore,omitempty"`
+	NotAfter          string   `json:"notAfter,omitempty"`
+	Expired           bool     `json:"expired,omitempty"`
+	AutoSsl           bool     `json:"autoSsl,omitempty"`
+	CreatedAt         string   `json:"createdAt,omitempty"`
+}
+
+func resourceCacheflyCertificate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCacheflyCertificateCreate,
+		ReadContext:   resourceCacheflyCertificateRead,
+		DeleteContext: resourceCacheflyCertificateDelete,
+
+		// No importer: the API never returns the private key, so an imported certificate
+		// would always be planned for replacement.
+
+		CustomizeDiff: validateCertificateKeyPairDiff,
+
+		Schema: map[string]*schema.Schema{
+			"certificate": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validatePEMCertificate,
+				Description:  "The PEM encoded leaf certificate.",
+			},
+			"certificate_chain": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validatePEMCertificate,
+				Description:  "The PEM encoded intermediate certificates, appended to the leaf certificate on upload.",
+			},
+			"private_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+				Description: "The PEM encoded private key of the certificate.",
+			},
+			"subject_common_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The common name of the certificate subject.",
+			},
+			"subject_names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The subject alternative names covered by the certificate.",
+			},
+			"issuer": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The issuer of the certificate.",
+			},
+			"not_before": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the certificate becomes valid.",
+			},
+			"not_after": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the certificate expires.",
+			},
+			"expired": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates whether the certificate has expired.",
+			},
+		},
+	}
+}
+
+func resourceCacheflyCertificateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	certificate := strings.TrimSpace(d.Get("certificate").(string))
+	if chain := strings.TrimSpace(d.Get("certificate_chain").(string)); chain != "" {
+		certificate += "\n" + chain
+	}
+
+	body := Certificate{
+		Certificate:    certificate,
+		CertificateKey: d.Get("private_key").(string),
+	}
+
+	resp, err := makeRequestWithRetry(client, "POST", fmt.Sprintf("%s/api/2.5/certificates", client.APIURL), body)
+	if err != nil {
+		return diag.Errorf("failed to upload certificate after retries: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if err := handleResponse(resp); err != nil {
+		return diag.Errorf("failed to upload certificate: %v", err)
+	}
+
+	var created Certificate
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return diag.Errorf("failed to decode certificate response: %v", err)
+	}
+
+	d.SetId(created.ID)
+
+	return resourceCacheflyCertificateRead(ctx, d, meta)
+}
+
+func resourceCacheflyCertificateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	certificate, err := fetchCertificate(client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if certificate == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("subject_common_name", certificate.SubjectCommonName)
+	d.Set("subject_names", certificate.SubjectNames)
+	d.Set("issuer", certificate.Issuer)
+	d.Set("not_before", certificate.NotBefore)
+	d.Set("not_after", certificate.NotAfter)
+	d.Set("expired", certificate.Expired)
+
+	return nil
+}
+
+func resourceCacheflyCertificateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	url := fmt.Sprintf("%s/api/2.5/certificates/%s", client.APIURL, d.Id())
+	resp, err := makeRequestWithRetry(client, "DELETE", url, nil)
+	if err != nil {
+		return diag.Errorf("failed to delete certificate after retries: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err := handleResponse(resp); err != nil {
+		return diag.Errorf("failed to delete certificate: %v", err)
+	}
+
+	return nil
+}
+
+// fetchCertificate returns the certificate with the given ID, or nil if it does not exist.
+func fetchCertificate(client *CacheFlyClient, certificateID string) (*Certificate, error) {
+	url := fmt.Sprintf("%s/api/2.5/certificates/%s", client.APIURL, certificateID)
+	resp, err := makeRequestWithRetry(client, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch certificate after retries: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	} else if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to fetch certificate: HTTP %d. Response: %s", resp.StatusCode, string(body))
+	}
+
+	var certificate Certificate
+	if err := json.NewDecoder(resp.Body).Decode(&certificate); err != nil {
+		return nil, fmt.Errorf("failed to decode certificate response: %w", err)
+	}
+
+	return &certificate, nil
+}
+
+// validatePEMCertificate checks that the value contains at least one parseable PEM certificate.
+func validatePEMCertificate(val interface{}, key string) (warns []string, errs []error) {
+	rest := []byte(val.(string))
+	found := false
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			errs = append(errs, fmt.Errorf("%q contains an unexpected PEM block of type %s", key, block.Type))
+			return
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			errs = append(errs, fmt.Errorf("%q contains an invalid certificate: %v", key, err))
+			return
+		}
+		found = true
+	}
+	if !found {
+		errs = append(errs, fmt.Errorf("%q must contain at least one PEM encoded certificate", key))
+	}
+	return
+}
+
+// validateCertificateKeyPairDiff checks at plan time that the private key matches the certificate.
+func validateCertificateKeyPairDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("certificate") || !d.NewValueKnown("private_key") {
+		return nil
+	}
+
+	certificate := d.Get("certificate").(string)
+	privateKey := d.Get("private_key").(string)
+	if certificate == "" || privateKey == "" {
+		return nil
+	}
+
+	if _, err := tls.X509KeyPair([]byte(certificate), []byte(privateKey)); err != nil {
+		return fmt.Errorf("private_key does not match certificate: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package cachefly
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccCertificateResource = "cachefly_certificate.test"
+
+func TestAccCacheflyCertificate_basic(t *testing.T) {
+	srv := newTestServer(t)
+	first, firstKey := testAccCertificatePEM(t, "www.example.com")
+	second, secondKey := testAccCertificatePEM(t, "cdn.example.com")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckCertificatesDeleted(srv),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCertificateConfig(srv, first, secondKey),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`private_key does not match certificate`),
+			},
+			{
+				Config: testAccCertificateConfig(srv, first, firstKey),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCertificateResource, "subject_common_name", "www.example.com"),
+					resource.TestCheckResourceAttr(testAccCertificateResource, "subject_names.#", "1"),
+					resource.TestCheckResourceAttr(testAccCertificateResource, "expired", "false"),
+					resource.TestCheckResourceAttrSet(testAccCertificateResource, "not_after"),
+					testAccCheckFakeCertificates(srv, "www.example.com"),
+				),
+			},
+			{
+				// Every attribute forces a new certificate, the old one is deleted afterwards.
+				Config: testAccCertificateConfig(srv, second, secondKey),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccCertificateResource, "subject_common_name", "cdn.example.com"),
+					testAccCheckFakeCertificates(srv, "cdn.example.com"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCertificateConfig(srv *fakeapi.Server, certificate, privateKey string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "cachefly_certificate" "test" {
+  certificate = %q
+  private_key = %q
+}
+`, certificate, privateKey)
+}
+
+// testAccCertificatePEM returns a self-signed certificate for hostname and its private key, PEM encoded.
+func testAccCertificatePEM(t *testing.T, hostname string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: hostname},
+		Issuer:       pkix.Name{CommonName: hostname},
+		DNSNames:     []string{hostname},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+// testAccCheckFakeCertificates checks the common names of the certificates stored by the API.
+func testAccCheckFakeCertificates(srv *fakeapi.Server, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var names []string
+		for _, certificate := range srv.Certificates() {
+			names = append(names, certificate.SubjectCommonName)
+		}
+		if fmt.Sprint(names) != fmt.Sprint(expected) {
+			return fmt.Errorf("expected certificates %v in the API, got %v", expected, names)
+		}
+		return nil
+	}
+}
+
+func testAccCheckCertificatesDeleted(srv *fakeapi.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if certificates := srv.Certificates(); len(certificates) != 0 {
+			return fmt.Errorf("expected every certificate to be deleted, got %d", len(certificates))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,314 @@
+package cachefly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// LogTarget represents an access log delivery target. Credentials and headers are never returned by the
+// API, the configured values are kept in the state as sensitive attributes.
+type LogTarget struct {
+	ID                 string            `json:"_id,omitempty"`
+	Name               string            `json:"name"`
+	Type               string            `json:"type"`
+	Endpoint           string            `json:"endpoint,omitempty"`
+	Bucket             string            `json:"bucket,omitempty"`
+	Prefix             string            `json:"prefix,omitempty"`
+	Region             string            `json:"region,omitempty"`
+	AccessKey          string            `json:"accessKey,omitempty"`
+	SecretKey          string            `json:"secretKey,omitempty"`
+	ServiceAccountJSON string            `json:"serviceAccountJson,omitempty"`
+	Headers            map[string]string `json:"headers,omitempty"`
+	Format             string            `json:"format"`
+	SamplingRate       float64           `json:"samplingRate"`
+	Services           []string          `json:"services"`
+}
+
+var (
+	logTargetTypes   = []string{"S3", "GCS", "HTTP", "SYSLOG"}
+	logTargetFormats = []string{"JSON", "CLF", "W3C"}
+)
+
+func resourceCacheflyLogTarget() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCacheflyLogTargetCreate,
+		ReadContext:   resourceCacheflyLogTargetRead,
+		UpdateContext: resourceCacheflyLogTargetUpdate,
+		DeleteContext: resourceCacheflyLogTargetDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: validateLogTargetDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the log target.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(logTargetTypes, false),
+				Description:  "The kind of destination. Possible values: S3, GCS, HTTP, SYSLOG.",
+			},
+			"endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The URL logs are posted to for HTTP targets, host:port for SYSLOG targets, or a custom S3 compatible endpoint.",
+			},
+			"bucket": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The bucket logs are written to. Required for S3 and GCS targets.",
+			},
+			"prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The object key prefix of the log files in the bucket.",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The region of the S3 bucket.",
+			},
+			"access_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The access key for S3 targets.",
+			},
+			"secret_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"access_key"},
+				Description:  "The secret key for S3 targets.",
+			},
+			"service_account_json": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "The service account key, in JSON, for GCS targets.",
+			},
+			"headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Headers sent with every request to HTTP targets, e.g. an Authorization header.",
+			},
+			"format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "JSON",
+				ValidateFunc: validation.StringInSlice(logTargetFormats, false),
+				Description:  "The format of the log lines. Possible values: JSON, CLF, W3C.",
+			},
+			"sampling_rate": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      1.0,
+				ValidateFunc: validation.FloatBetween(0.0001, 1),
+				Description:  "The fraction of requests logged, between 0.0001 and 1.",
+			},
+			"service_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The services whose access logs are delivered to this target.",
+			},
+		},
+	}
+}
+
+func resourceCacheflyLogTargetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	resp, err := makeRequestWithRetry(client, "POST", fmt.Sprintf("%s/api/2.5/logtargets", client.APIURL), expandLogTarget(d))
+	if err != nil {
+		return diag.Errorf("failed to create log target after retries: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if err := handleResponse(resp); err != nil {
+		return diag.Errorf("failed to create log target: %v", err)
+	}
+
+	var created LogTarget
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return diag.Errorf("failed to decode log target response: %v", err)
+	}
+
+	d.SetId(created.ID)
+
+	return resourceCacheflyLogTargetRead(ctx, d, meta)
+}
+
+func resourceCacheflyLogTargetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	url := fmt.Sprintf("%s/api/2.5/logtargets/%s", client.APIURL, d.Id())
+	resp, err := makeRequestWithRetry(client, "GET", url, nil)
+	if err != nil {
+		return diag.Errorf("failed to fetch log target after retries: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		d.SetId("")
+		return nil
+	} else if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return diag.Errorf("failed to fetch log target: HTTP %d. Response: %s", resp.StatusCode, string(body))
+	}
+
+	var target LogTarget
+	if err := json.NewDecoder(resp.Body).Decode(&target); err != nil {
+		return diag.Errorf("failed to decode log target response: %v", err)
+	}
+
+	// Credentials and headers are not returned, the configured values are kept in the state.
+	d.Set("name", target.Name)
+	d.Set("type", target.Type)
+	d.Set("endpoint", target.Endpoint)
+	d.Set("bucket", target.Bucket)
+	d.Set("prefix", target.Prefix)
+	d.Set("region", target.Region)
+	d.Set("format", target.Format)
+	d.Set("sampling_rate", target.SamplingRate)
+	d.Set("service_ids", target.Services)
+
+	return nil
+}
+
+func resourceCacheflyLogTargetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	url := fmt.Sprintf("%s/api/2.5/logtargets/%s", client.APIURL, d.Id())
+	resp, err := makeRequestWithRetry(client, "PUT", url, expandLogTarget(d))
+	if err != nil {
+		return diag.Errorf("failed to update log target after retries: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if err := handleResponse(resp); err != nil {
+		return diag.Errorf("failed to update log target: %v", err)
+	}
+
+	return resourceCacheflyLogTargetRead(ctx, d, meta)
+}
+
+func resourceCacheflyLogTargetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	url := fmt.Sprintf("%s/api/2.5/logtargets/%s", client.APIURL, d.Id())
+	resp, err := makeRequestWithRetry(client, "DELETE", url, nil)
+	if err != nil {
+		return diag.Errorf("failed to delete log target after retries: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err := handleResponse(resp); err != nil {
+		return diag.Errorf("failed to delete log target: %v", err)
+	}
+
+	return nil
+}
+
+func expandLogTarget(d *schema.ResourceData) LogTarget {
+	headers := make(map[string]string)
+	for key, value := range d.Get("headers").(map[string]interface{}) {
+		headers[key] = value.(string)
+	}
+
+	return LogTarget{
+		Name:               d.Get("name").(string),
+		Type:               d.Get("type").(string),
+		Endpoint:           d.Get("endpoint").(string),
+		Bucket:             d.Get("bucket").(string),
+		Prefix:             d.Get("prefix").(string),
+		Region:             d.Get("region").(string),
+		AccessKey:          d.Get("access_key").(string),
+		SecretKey:          d.Get("secret_key").(string),
+		ServiceAccountJSON: d.Get("service_account_json").(string),
+		Headers:            headers,
+		Format:             d.Get("format").(string),
+		SamplingRate:       d.Get("sampling_rate").(float64),
+		Services:           expandSortedSet(d.Get("service_ids")),
+	}
+}
+
+// validateLogTargetDiff checks that the destination attributes match the target type.
+func validateLogTargetDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("type") {
+		return nil
+	}
+	targetType := d.Get("type").(string)
+
+	known := func(attrs ...string) bool {
+		for _, attr := range attrs {
+			if !d.NewValueKnown(attr) {
+				return false
+			}
+		}
+		return true
+	}
+	set := func(attr string) bool {
+		return d.Get(attr).(string) != ""
+	}
+
+	switch targetType {
+	case "S3", "GCS":
+		if known("bucket") && !set("bucket") {
+			return fmt.Errorf("bucket is required for %s log targets", targetType)
+		}
+		if targetType == "S3" && known("endpoint") && set("endpoint") {
+			if _, err := url.ParseRequestURI(d.Get("endpoint").(string)); err != nil {
+				return fmt.Errorf("endpoint must be a URL for S3 log targets: %v", err)
+			}
+		}
+		if targetType == "GCS" && known("access_key") && set("access_key") {
+			return fmt.Errorf("access_key is only supported for S3 log targets, use service_account_json for GCS")
+		}
+		if targetType == "S3" && known("service_account_json") && set("service_account_json") {
+			return fmt.Errorf("service_account_json is only supported for GCS log targets")
+		}
+	case "HTTP":
+		if !known("endpoint") {
+			return nil
+		}
+		u, err := url.Parse(d.Get("endpoint").(string))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("endpoint must be an http or https URL for HTTP log targets")
+		}
+	case "SYSLOG":
+		if !known("endpoint") {
+			return nil
+		}
+		if _, _, err := net.SplitHostPort(d.Get("endpoint").(string)); err != nil {
+			return fmt.Errorf("endpoint must be host:port for SYSLOG log targets: %v", err)
+		}
+	}
+
+	if targetType != "S3" && targetType != "GCS" && known("bucket") && set("bucket") {
+		return fmt.Errorf("bucket is only supported for S3 and GCS log targets")
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package cachefly
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccLogTargetResource = "cachefly_log_target.test"
+
+func TestAccCacheflyLogTarget_basic(t *testing.T) {
+	srv := newTestServer(t)
+	service := srv.AddService(fakeapi.Service{Name: "Logged", UniqueName: "logged"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckLogTargetsDeleted(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLogTargetConfig(srv, `
+  name     = "collector"
+  type     = "SYSLOG"
+  endpoint = "logs.example.com"
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`endpoint must be host:port for SYSLOG log targets`),
+			},
+			{
+				Config: testAccLogTargetConfig(srv, `
+  name       = "archive"
+  type       = "S3"
+  bucket     = "logs"
+  region     = "us-east-1"
+  access_key = "AKIAEXAMPLE"
+  secret_key = "secret-example"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccLogTargetResource, "format", "JSON"),
+					resource.TestCheckResourceAttr(testAccLogTargetResource, "sampling_rate", "1"),
+					testAccCheckFakeLogTarget(srv, func(target fakeapi.LogTarget) error {
+						if target.Bucket != "logs" || target.AccessKey != "AKIAEXAMPLE" || target.SecretKey != "secret-example" {
+							return fmt.Errorf("unexpected log target in the API: %+v", target)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccLogTargetConfig(srv, fmt.Sprintf(`
+  name          = "archive"
+  type          = "S3"
+  bucket        = "logs"
+  prefix        = "cdn/"
+  region        = "us-east-1"
+  access_key    = "AKIAEXAMPLE"
+  secret_key    = "secret-rotated"
+  format        = "W3C"
+  sampling_rate = 0.5
+  service_ids   = [%q]
+`, service.ID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccLogTargetResource, "prefix", "cdn/"),
+					resource.TestCheckResourceAttr(testAccLogTargetResource, "sampling_rate", "0.5"),
+					resource.TestCheckResourceAttr(testAccLogTargetResource, "service_ids.#", "1"),
+					testAccCheckFakeLogTarget(srv, func(target fakeapi.LogTarget) error {
+						if target.SecretKey != "secret-rotated" || target.Format != "W3C" || fmt.Sprint(target.Services) != fmt.Sprint([]string{service.ID}) {
+							return fmt.Errorf("unexpected log target in the API: %+v", target)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:            testAccLogTargetResource,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"access_key", "secret_key"},
+			},
+		},
+	})
+}
+
+func TestAccCacheflyLogTarget_planValidation(t *testing.T) {
+	srv := newTestServer(t)
+
+	tests := map[string]struct {
+		body     string
+		expected string
+	}{
+		"s3 without bucket": {
+			body: `
+  name = "archive"
+  type = "S3"
+`,
+			expected: `bucket is required for S3 log targets`,
+		},
+		"s3 endpoint not a url": {
+			body: `
+  name     = "archive"
+  type     = "S3"
+  bucket   = "logs"
+  endpoint = "minio.example.com"
+`,
+			expected: `endpoint must be a URL for S3 log targets`,
+		},
+		"gcs with access key": {
+			body: `
+  name       = "archive"
+  type       = "GCS"
+  bucket     = "logs"
+  access_key = "AKIAEXAMPLE"
+`,
+			expected: `access_key is only supported for S3 log targets`,
+		},
+		"s3 with service account": {
+			body: `
+  name                 = "archive"
+  type                 = "S3"
+  bucket               = "logs"
+  service_account_json = "{}"
+`,
+			expected: `service_account_json is only supported for GCS log targets`,
+		},
+		"http without endpoint": {
+			body: `
+  name = "collector"
+  type = "HTTP"
+`,
+			expected: `endpoint must be an http or https URL for HTTP log targets`,
+		},
+		"http endpoint scheme": {
+			body: `
+  name     = "collector"
+  type     = "HTTP"
+  endpoint = "ftp://logs.example.com/ingest"
+`,
+			expected: `endpoint must be an http or https URL for HTTP log targets`,
+		},
+		"syslog with bucket": {
+			body: `
+  name     = "collector"
+  type     = "SYSLOG"
+  endpoint = "logs.example.com:514"
+  bucket   = "logs"
+`,
+			expected: `bucket is only supported for S3 and GCS log targets`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccLogTargetConfig(srv, tc.body),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(regexp.QuoteMeta(tc.expected)),
+					},
+				},
+			})
+		})
+	}
+}
+
+// Values only known after apply must not be rejected at plan time.
+func TestAccCacheflyLogTarget_planValidationUnknownValues(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLogTargetConfig(srv, `
+  name     = "collector"
+  type     = "HTTP"
+  endpoint = "https://${terraform_data.generated.id}.example.com/ingest"
+`) + `
+resource "cachefly_log_target" "archive" {
+  name       = "archive"
+  type       = "S3"
+  bucket     = terraform_data.generated.id
+  endpoint   = terraform_data.generated.id
+  access_key = terraform_data.generated.id
+}
+
+resource "cachefly_log_target" "syslog" {
+  name     = "syslog"
+  type     = "SYSLOG"
+  endpoint = terraform_data.generated.id
+}
+
+resource "terraform_data" "generated" {
+  input = "generated"
+}
+`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccLogTargetConfig(srv *fakeapi.Server, body string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "cachefly_log_target" "test" {
+%s}
+`, body)
+}
+
+func testAccCheckFakeLogTarget(srv *fakeapi.Server, check func(fakeapi.LogTarget) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[testAccLogTargetResource]
+		if !ok {
+			return fmt.Errorf("%s not found in state", testAccLogTargetResource)
+		}
+		for _, target := range srv.LogTargets() {
+			if target.ID == rs.Primary.ID {
+				return check(target)
+			}
+		}
+		return fmt.Errorf("log target %s not found in the API", rs.Primary.ID)
+	}
+}
+
+func testAccCheckLogTargetsDeleted(srv *fakeapi.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if targets := srv.LogTargets(); len(targets) != 0 {
+			return fmt.Errorf("expected every log target to be deleted, got %d", len(targets))
+		}
+		return nil
+	}
+}
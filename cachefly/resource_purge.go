@@ -0,0 +1,237 @@
+package cachefly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Purge represents a cache purge request returned by the API.
+type Purge struct {
+	ID          string   `json:"_id,omitempty"`
+	Paths       []string `json:"paths"`
+	Status      string   `json:"status,omitempty"`
+	CreatedAt   string   `json:"createdAt,omitempty"`
+	CompletedAt string   `json:"completedAt,omitempty"`
+}
+
+func resourceCacheflyPurge() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCacheflyPurgeCreate,
+		ReadContext:   resourceCacheflyPurgeRead,
+		UpdateContext: resourceCacheflyPurgeUpdate,
+		DeleteContext: resourceCacheflyPurgeDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the service to purge content from.",
+			},
+			"paths": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validatePurgePath},
+				Description: "Paths or wildcard patterns to purge, e.g. /index.html or /assets/*. Use /* to purge the whole service.",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that issue a new purge whenever they change, e.g. a hash of the deployed assets.",
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait until CacheFly reports the purge as completed.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the purge request.",
+			},
+			"purged_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the purge was requested.",
+			},
+		},
+	}
+}
+
+func resourceCacheflyPurgeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+	serviceID := d.Get("service_id").(string)
+
+	body := Purge{
+		Paths: normalizePurgePaths(expandStringList(d.Get("paths"))),
+	}
+
+	url := fmt.Sprintf("%s/api/2.5/services/%s/purge", client.APIURL, serviceID)
+	resp, err := makeRequestWithRetry(client, "POST", url, body)
+	if err != nil {
+		return diag.Errorf("failed to purge service after retries: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if err := handleResponse(resp); err != nil {
+		return diag.Errorf("failed to purge service: %v", err)
+	}
+
+	var purge Purge
+	if err := json.NewDecoder(resp.Body).Decode(&purge); err != nil {
+		return diag.Errorf("failed to decode purge response: %v", err)
+	}
+
+	var diags diag.Diagnostics
+	wait := d.Get("wait_for_completion").(bool)
+	trackable := purge.ID != ""
+	if !trackable {
+		// Purges without an ID cannot be polled, the resource only records that one was issued.
+		purge.ID = id.UniqueId()
+		if wait {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Purge completion not awaited",
+				Detail: fmt.Sprintf("The API did not return an ID for the purge of service %s, so its progress cannot be "+
+					"polled and wait_for_completion was ignored. The purge was requested and may still be in progress.", serviceID),
+			})
+		}
+	}
+
+	// The purge has been issued, record it before waiting so a failure taints it rather
+	// than losing track of it.
+	d.SetId(purge.ID)
+	d.Set("status", purge.Status)
+	purgedAt := purge.CreatedAt
+	if purgedAt == "" {
+		purgedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	d.Set("purged_at", purgedAt)
+
+	if wait && trackable {
+		polled, err := waitForPurge(ctx, client, serviceID, purge.ID, d.Timeout(schema.TimeoutCreate))
+		if polled.Status != "" {
+			d.Set("status", polled.Status)
+		}
+		if err != nil {
+			return diag.Errorf("failed waiting for purge %s: %v", purge.ID, err)
+		}
+	}
+
+	return append(diags, resourceCacheflyPurgeRead(ctx, d, meta)...)
+}
+
+// A purge is a one-off action, there is no remote object to refresh.
+func resourceCacheflyPurgeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+// Only wait_for_completion can change in place, and it only affects future purges.
+func resourceCacheflyPurgeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceCacheflyPurgeRead(ctx, d, meta)
+}
+
+// Purged content cannot be restored, removing the resource only drops it from the state.
+func resourceCacheflyPurgeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// waitForPurge polls a purge request until it is completed. The last polled state is
+// returned even when waiting fails.
+func waitForPurge(ctx context.Context, client *CacheFlyClient, serviceID, purgeID string, timeout time.Duration) (*Purge, error) {
+	url := fmt.Sprintf("%s/api/2.5/services/%s/purge/%s", client.APIURL, serviceID, purgeID)
+
+	var purge Purge
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		resp, err := makeRequestWithRetry(client, "GET", url, nil)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			return retry.NonRetryableError(fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body)))
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&purge); err != nil {
+			return retry.NonRetryableError(fmt.Errorf("failed to decode purge response: %w", err))
+		}
+
+		switch purge.Status {
+		case "COMPLETED":
+			return nil
+		case "FAILED":
+			return retry.NonRetryableError(fmt.Errorf("purge failed"))
+		default:
+			return retry.RetryableError(fmt.Errorf("purge is %s", purge.Status))
+		}
+	})
+	return &purge, err
+}
+
+// validatePurgePath checks that a purge path is absolute.
+func validatePurgePath(val interface{}, key string) (warns []string, errs []error) {
+	v := strings.TrimSpace(val.(string))
+	if v == "" {
+		errs = append(errs, fmt.Errorf("%q must not be empty", key))
+	} else if v != "*" && !strings.HasPrefix(v, "/") {
+		errs = append(errs, fmt.Errorf("%q must start with '/' or be '*'. Found: %s", key, v))
+	}
+	return
+}
+
+// normalizePurgePaths trims, cleans and de-duplicates purge paths. A full purge pattern
+// makes every other path redundant, so it is returned on its own.
+func normalizePurgePaths(paths []string) []string {
+	seen := make(map[string]bool)
+	normalized := make([]string, 0, len(paths))
+
+	for _, p := range paths {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !strings.HasPrefix(p, "/") {
+			p = "/" + p
+		}
+
+		trailingSlash := strings.HasSuffix(p, "/") && p != "/"
+		p = path.Clean(p)
+		if trailingSlash {
+			p += "/"
+		}
+
+		if p == "/*" {
+			return []string{"/*"}
+		}
+		if !seen[p] {
+			seen[p] = true
+			normalized = append(normalized, p)
+		}
+	}
+
+	sort.Strings(normalized)
+	return normalized
+}
@@ -0,0 +1,163 @@
+package cachefly
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccPurgeResource = "cachefly_purge.test"
+
+func TestAccCacheflyPurge_basic(t *testing.T) {
+	srv := newTestServer(t)
+	service := srv.AddService(fakeapi.Service{Name: "Purge", UniqueName: "purge"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPurgeConfig(srv, service.ID, `
+  paths               = ["/index.html", " /index.html", "/assets/*"]
+  wait_for_completion = true
+  triggers = {
+    release = "1"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccPurgeResource, "status", "COMPLETED"),
+					resource.TestCheckResourceAttrSet(testAccPurgeResource, "purged_at"),
+					testAccCheckFakePurges(srv, "[/assets/* /index.html]"),
+				),
+			},
+			{
+				// wait_for_completion only affects future purges and changes in place.
+				Config: testAccPurgeConfig(srv, service.ID, `
+  paths = ["/index.html", " /index.html", "/assets/*"]
+  triggers = {
+    release = "1"
+  }
+`),
+				Check: testAccCheckFakePurges(srv, "[/assets/* /index.html]"),
+			},
+			{
+				Config: testAccPurgeConfig(srv, service.ID, `
+  paths = ["/*", "/index.html"]
+  triggers = {
+    release = "2"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccPurgeResource, "status", "PENDING"),
+					testAccCheckFakePurges(srv, "[/assets/* /index.html]", "[/*]"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCacheflyPurge_failedPurgeIsTainted(t *testing.T) {
+	srv := newTestServer(t)
+	service := srv.AddService(fakeapi.Service{Name: "Purge", UniqueName: "purge"})
+	srv.PurgeStatus = "FAILED"
+
+	config := testAccPurgeConfig(srv, service.ID, `
+  paths               = ["/*"]
+  wait_for_completion = true
+`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`failed waiting for purge .*: purge failed`),
+			},
+			{
+				// The failed purge is kept in the state, tainted, so the next apply issues it again.
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccPurgeResource, "status", "FAILED"),
+					func(s *terraform.State) error {
+						if rs := s.RootModule().Resources[testAccPurgeResource]; !rs.Primary.Tainted {
+							return fmt.Errorf("expected the failed purge to be tainted")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() { srv.PurgeStatus = "" },
+				Config:    config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccPurgeResource, "status", "COMPLETED"),
+					testAccCheckFakePurges(srv, "[/*]", "[/*]"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCacheflyPurge_withoutID(t *testing.T) {
+	srv := newTestServer(t)
+	service := srv.AddService(fakeapi.Service{Name: "Purge", UniqueName: "purge"})
+	srv.InjectFault(fakeapi.Fault{
+		Method: "POST",
+		Path:   "/api/2.5/services/*/purge",
+		Status: http.StatusAccepted,
+		Body:   `{"paths":["/*"],"status":"QUEUED"}`,
+		Times:  1,
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPurgeConfig(srv, service.ID, `
+  paths               = ["/*"]
+  wait_for_completion = true
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccPurgeResource, "status", "QUEUED"),
+					resource.TestCheckResourceAttrSet(testAccPurgeResource, "id"),
+					func(s *terraform.State) error {
+						for _, request := range srv.Requests() {
+							if request.Method == "GET" && strings.Contains(request.Path, "/purge/") {
+								return fmt.Errorf("expected a purge without an ID not to be polled, got GET %s", request.Path)
+							}
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccPurgeConfig(srv *fakeapi.Server, serviceID, body string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "cachefly_purge" "test" {
+  service_id = %q
+%s}
+`, serviceID, body)
+}
+
+// testAccCheckFakePurges checks the paths of every purge received by the API, in order.
+func testAccCheckFakePurges(srv *fakeapi.Server, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var got []string
+		for _, purge := range srv.Purges() {
+			got = append(got, fmt.Sprint(purge.Paths))
+		}
+		if fmt.Sprint(got) != fmt.Sprint(expected) {
+			return fmt.Errorf("expected purges %v in the API, got %v", expected, got)
+		}
+		return nil
+	}
+}
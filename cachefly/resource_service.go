@@ -3,13 +3,21 @@ package cachefly
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -20,7 +28,7 @@ type ServiceResource struct {
 	CreatedAt         string `json:"createdAt,omitempty"`
 	Name              string `json:"name"`
 	UniqueName        string `json:"uniqueName"`
-	Description       string `json:"description,omitempty"`
+	Description       string `json:"description"`
 	AutoSsl           bool   `json:"autoSsl,omitempty"`
 	ConfigurationMode string `json:"configurationMode,omitempty"`
 	Status            string `json:"status,omitempty"`
@@ -64,6 +72,8 @@ type ServiceOptions struct {
 	ErrorTTL            *ErrorTTL     `json:"error_ttl"`
 	SharedShield        *SharedShield `json:"sharedshield"`
 	HostnamePassThrough bool          `json:"edgetoorigin"`
+	Cors                bool          `json:"cors"`
+	AutoRedirect        bool          `json:"autoRedirect"`
 }
 
 type SharedShield struct {
@@ -82,6 +92,22 @@ func resourceCacheflyService() *schema.Resource {
 			StateContext: resourceCacheflyServiceImport,
 		},
 
+		CustomizeDiff: resourceCacheflyServiceCustomizeDiff(),
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceCacheflyServiceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceCacheflyServiceStateUpgradeV0,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -102,14 +128,69 @@ func resourceCacheflyService() *schema.Resource {
 			},
 			"auto_ssl": {
 				Type:        schema.TypeBool,
+				Optional:    true,
 				Computed:    true,
-				Description: "Indicates whether AutoSSL is enabled for the service.",
+				Description: "Enable AutoSSL, which provisions Let's Encrypt certificates for the validated domains of the service.",
+			},
+			"wait_for_certificates": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "When auto_ssl is enabled, wait until certificates are issued for every validated domain. Certificates not issued within the create or update timeout only produce a warning.",
+			},
+			"domain_certificates": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The certificate status of each domain of the service.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The domain name.",
+						},
+						"validation_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The validation status of the domain.",
+						},
+						"certificate_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The IDs of the certificates associated with the domain.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ISSUED, PENDING_ISSUANCE, PENDING_VALIDATION, or NONE when the domain has no certificate and auto_ssl is disabled, so none will be issued.",
+						},
+					},
+				},
 			},
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "The status of the service (e.g., ACTIVE, Pending Configuration, DEACTIVATED).",
 			},
+			"credentials_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "HMAC-SHA256 of the reverse proxy object storage credentials keyed on `unique_name`, used to detect drift without storing the secret.",
+			},
+			"deletion_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "deactivate",
+				ValidateFunc: validation.StringInSlice([]string{"deactivate", "delete", "abandon"}, false),
+				Description:  "What happens to the service on destroy: 'deactivate' it, 'delete' it permanently, or 'abandon' it by only removing it from the state.",
+			},
+			"reactivate_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Adopt a DEACTIVATED service with the same unique_name instead of failing. The adopted service is reactivated and its name, description, options and domains are reset to match the configuration.",
+			},
 			"domains": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -132,6 +213,12 @@ func resourceCacheflyService() *schema.Resource {
 							ValidateFunc: validation.StringInSlice([]string{"NONE", "MANUAL", "HTTP", "DNS"}, false),
 							Description:  "The validation mode for the domain.",
 						},
+						"certificates": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "IDs of custom certificates to associate with the domain, e.g. from cachefly_certificate. When omitted, the certificate associations of the domain are left unchanged; an empty list detaches every certificate.",
+						},
 					},
 				},
 				Description: "A list of domains associated with the service.",
@@ -196,7 +283,7 @@ func resourceCacheflyService() *schema.Resource {
 						"value": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							Description: "The value for the Shared Origin Shield (e.g., region).",
+							Description: "The three letter code of the POP used as the shield, e.g. IAD.",
 						},
 					},
 				},
@@ -246,18 +333,39 @@ func reverseProxySchema() map[string]*schema.Schema {
 			Description: "Specifies whether to respect the robots.txt file. Required for all modes.",
 		},
 		"access_key": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Description:   "The access key for the OBJECT_STORAGE mode. Required for OBJECT_STORAGE unless access_key_wo is set.",
+			Sensitive:     true,
+			Deprecated:    "Use access_key_wo instead to keep the access key out of the Terraform state.",
+			ConflictsWith: []string{"reverse_proxy.0.access_key_wo"},
+		},
+		"secret_key": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Description:   "The secret key for the OBJECT_STORAGE mode. Required for OBJECT_STORAGE unless secret_key_wo is set.",
+			Sensitive:     true,
+			Deprecated:    "Use secret_key_wo instead to keep the secret key out of the Terraform state.",
+			ConflictsWith: []string{"reverse_proxy.0.secret_key_wo"},
+		},
+		"access_key_wo": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Computed:    true,
-			Description: "The access key for the OBJECT_STORAGE mode. Required for OBJECT_STORAGE.",
+			WriteOnly:   true,
 			Sensitive:   true,
+			Description: "Write-only access key for the OBJECT_STORAGE mode. It is never stored in the Terraform state. Requires Terraform 1.11 or later.",
 		},
-		"secret_key": {
+		"secret_key_wo": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Computed:    true,
-			Description: "The secret key for the OBJECT_STORAGE mode. Required for OBJECT_STORAGE.",
+			WriteOnly:   true,
 			Sensitive:   true,
+			Description: "Write-only secret key for the OBJECT_STORAGE mode. It is never stored in the Terraform state. Requires Terraform 1.11 or later.",
+		},
+		"secret_key_version": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Change this value to send the object storage credentials to CacheFly again, e.g. to rotate the secret key.",
 		},
 		"region": {
 			Type:        schema.TypeString,
@@ -283,16 +391,11 @@ func resourceCacheflyServiceCreate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	if existingService != nil {
-		if existingService.Status == "DEACTIVATED" {
-			// Reactivate the service if it is deactivated
-			if err := reactivateService(client, existingService.ID); err != nil {
-				return diag.Errorf("Failed to reactivate service: %v", err)
-			}
-			d.SetId(existingService.ID)
-			return resourceCacheflyServiceRead(ctx, d, meta)
+		if existingService.Status != "DEACTIVATED" {
+			// Service exists but is active
+			return diag.Errorf("Service with unique_name '%s' already exists.", uniqueName)
 		}
-		// Service exists but is active
-		return diag.Errorf("Service with unique_name '%s' already exists.", uniqueName)
+		return adoptDeactivatedService(ctx, d, meta, existingService)
 	}
 
 	// Create a new service if none exists
@@ -317,115 +420,255 @@ func resourceCacheflyServiceCreate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	// The remaining steps run against a live service. If any of them fails the service is
+	// rolled back, so a failed apply never leaves a half-configured service behind.
+	steps := []serviceCreateStep{
+		{"service options", func() error { return applyServiceOptions(client, d, createdService.ID, true) }},
+		{"domains", func() error { return manageAdditionalConfigurations(client, d, createdService.ID) }},
+		{"auto_ssl", func() error { return applyAutoSsl(client, d, createdService.ID) }},
+	}
+	if diags := runServiceCreateSteps(client, d, createdService.ID, steps); diags.HasError() {
+		return diags
+	}
+
 	d.SetId(createdService.ID)
 
-	// Configure reverse proxy if provided
-	if v, ok := d.GetOk("reverse_proxy"); ok {
-		reverseProxy := v.([]interface{})[0].(map[string]interface{})
-		proxyConfig := ReverseProxy{
-			Enabled:           true, // Automatically enabled if reverse_proxy block is provided
-			Hostname:          reverseProxy["hostname"].(string),
-			Mode:              reverseProxy["mode"].(string),
-			OriginScheme:      reverseProxy["origin_scheme"].(string),
-			CacheByQueryParam: reverseProxy["cache_by_query_param"].(bool),
-			TTL:               reverseProxy["ttl"].(int),
-			UseRobotsTxt:      reverseProxy["use_robots_txt"].(bool),
-		}
+	// The service is fully configured at this point, waiting for certificates is not a
+	// configuration step and never rolls it back.
+	diags := waitForAutoSslCertificates(ctx, client, d, createdService.ID, schema.TimeoutCreate)
 
-		if reverseProxy["prepend"] != nil {
-			proxyConfig.Prepend = reverseProxy["prepend"].(string)
-		}
+	return append(diags, resourceCacheflyServiceRead(ctx, d, meta)...)
+}
 
-		if reverseProxy["access_key"] != nil {
-			proxyConfig.AccessKey = reverseProxy["access_key"].(string)
-		}
-		if reverseProxy["secret_key"] != nil {
-			proxyConfig.SecretKey = reverseProxy["secret_key"].(string)
-		}
-		if reverseProxy["region"] != nil {
-			proxyConfig.Region = reverseProxy["region"].(string)
-		}
-		if reverseProxy["bucket"] != nil {
-			proxyConfig.Bucket = reverseProxy["bucket"].(string)
-		}
+// adoptDeactivatedService reactivates a DEACTIVATED service with the configured unique_name
+// and resets its details, options and domains to match the configuration.
+func adoptDeactivatedService(ctx context.Context, d *schema.ResourceData, meta interface{}, existing *ServiceResource) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
 
-		// Configuring reverse proxy
-		err := configureReverseProxy(client, createdService.ID, proxyConfig)
-		if err != nil {
-			return diag.Errorf("failed to configure reverse proxy: %v", err)
+	if !d.Get("reactivate_existing").(bool) {
+		return diag.Errorf("Service with unique_name '%s' already exists and is DEACTIVATED (ID %s). "+
+			"Set reactivate_existing = true to adopt it, or choose another unique_name.", existing.UniqueName, existing.ID)
+	}
+
+	if err := reactivateService(client, existing.ID); err != nil {
+		return diag.Errorf("Failed to reactivate service: %v", err)
+	}
+
+	details := ServiceResource{
+		Name:        d.Get("name").(string),
+		UniqueName:  existing.UniqueName,
+		Description: d.Get("description").(string),
+	}
+	steps := []serviceCreateStep{
+		{"service details", func() error { return updateServiceDetails(client, existing.ID, &details) }},
+		{"service options", func() error { return applyServiceOptions(client, d, existing.ID, true) }},
+		{"domains", func() error { return manageServiceDomains(client, existing.ID, desiredServiceDomains(d)) }},
+		{"auto_ssl", func() error { return applyAutoSsl(client, d, existing.ID) }},
+	}
+	if diags := runServiceCreateSteps(client, d, existing.ID, steps); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(existing.ID)
+
+	diags := diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Adopted an existing deactivated service",
+			Detail: fmt.Sprintf("A DEACTIVATED service with unique_name '%s' (ID %s) already existed. It has been "+
+				"reactivated and its name, description, options and domains were reset to match the configuration.",
+				existing.UniqueName, existing.ID),
+		},
+	}
+	diags = append(diags, waitForAutoSslCertificates(ctx, client, d, existing.ID, schema.TimeoutCreate)...)
+
+	return append(diags, resourceCacheflyServiceRead(ctx, d, meta)...)
+}
+
+// runServiceCreateSteps applies the steps in order and rolls the service back on the first failure.
+func runServiceCreateSteps(client *CacheFlyClient, d *schema.ResourceData, serviceID string, steps []serviceCreateStep) diag.Diagnostics {
+	for _, step := range steps {
+		if err := step.apply(); err != nil {
+			return rollbackServiceCreate(client, d, serviceID, step.name, err)
 		}
+	}
+	return nil
+}
+
+// serviceCreateStep is a named configuration step applied to a newly created service.
+type serviceCreateStep struct {
+	name  string
+	apply func() error
+}
+
+// rollbackServiceCreate deactivates a service whose configuration failed during Create and
+// returns a single diagnostic describing the failed step and the outcome of the rollback.
+func rollbackServiceCreate(client *CacheFlyClient, d *schema.ResourceData, serviceID, step string, stepErr error) diag.Diagnostics {
+	detail := fmt.Sprintf("Configuring %s for service %s failed: %v", step, serviceID, stepErr)
+
+	if err := deactivateService(client, serviceID); err != nil {
+		// Keep the ID so Terraform tracks the service as tainted and replaces it on the next apply.
+		d.SetId(serviceID)
+		detail += fmt.Sprintf("\n\nRolling back the service also failed: %v. The service is still active "+
+			"and is tracked as tainted, so it will be replaced on the next apply.", err)
 	} else {
-		// If reverse proxy is not provided, ensure it's disabled
-		err := configureReverseProxy(client, createdService.ID, ReverseProxy{Enabled: false})
-		if err != nil {
-			return diag.Errorf("failed to disable reverse proxy: %v", err)
-		}
+		d.SetId("")
+		detail += "\n\nThe service has been deactivated, no partially configured service was left active."
 	}
 
-	// Configure error_ttl if provided
-	if v, ok := d.GetOk("error_ttl"); ok {
-		errorTTLConfig := v.([]interface{})[0].(map[string]interface{})
-		errorTTL := map[string]interface{}{
-			"enabled": errorTTLConfig["enabled"].(bool),
-		}
-		if value, ok := errorTTLConfig["value"]; ok {
-			errorTTL["value"] = value.(int)
-		}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to configure %s for the new service", step),
+			Detail:   detail,
+		},
+	}
+}
 
-		// Send the error_ttl configuration to the API
-		payload := map[string]interface{}{
-			"error_ttl": errorTTL,
-		}
-		url := fmt.Sprintf("%s/api/2.6/services/%s/options", client.APIURL, createdService.ID)
-		resp, err := makeRequestWithRetry(client, "PUT", url, payload)
+// applyServiceOptions sends every option section that needs to change in a single PUT, so
+// the edge never sees an intermediate combination of options. When includeAll is set, as on
+// create, every section is sent regardless of the diff.
+func applyServiceOptions(client *CacheFlyClient, d *schema.ResourceData, serviceID string, includeAll bool) error {
+	payload, err := buildServiceOptionsPayload(d, includeAll)
+	if err != nil {
+		return err
+	}
+	if len(payload) == 0 {
+		return nil
+	}
+
+	if err := updateServiceOptions(client, serviceID, payload); err != nil {
+		return fmt.Errorf("failed to update service options: %w", err)
+	}
+	return nil
+}
+
+// buildServiceOptionsPayload merges the reverse proxy, error_ttl, shared shield, hostname
+// pass-through, CORS and HTTPS redirect sections into one options payload.
+func buildServiceOptionsPayload(d *schema.ResourceData, includeAll bool) (map[string]interface{}, error) {
+	payload := map[string]interface{}{}
+
+	if includeAll || d.HasChanges("reverse_proxy", "credentials_hash") {
+		// Credentials are only sent when they changed, so unrelated updates never resend the secret.
+		sendCredentials := includeAll || d.HasChanges("credentials_hash", "reverse_proxy.0.secret_key_version", "reverse_proxy.0.mode")
+		reverseProxy, err := reverseProxyOptions(d, sendCredentials)
 		if err != nil {
-			return diag.Errorf("failed to configure error_ttl: %v", err)
+			return nil, err
 		}
-		defer resp.Body.Close()
+		payload["reverseProxy"] = reverseProxy
+	}
 
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			return diag.Errorf("API error while configuring error_ttl: %s", string(body))
+	if includeAll || d.HasChange("error_ttl") {
+		if v, ok := d.GetOk("error_ttl"); ok {
+			errorTTLConfig := v.([]interface{})[0].(map[string]interface{})
+			errorTTL := map[string]interface{}{
+				"enabled": errorTTLConfig["enabled"].(bool),
+			}
+			if value, ok := errorTTLConfig["value"]; ok {
+				errorTTL["value"] = value.(int)
+			}
+			payload["error_ttl"] = errorTTL
+		} else {
+			payload["error_ttl"] = map[string]interface{}{"enabled": false}
 		}
 	}
 
-	// Configure shared_origin_shield if provided
-	if v, ok := d.GetOk("shared_origin_shield"); ok {
-		sharedShieldConfig := v.([]interface{})[0].(map[string]interface{})
-		sharedShield := map[string]interface{}{
-			"enabled": sharedShieldConfig["enabled"].(bool),
-		}
-		if value, exists := sharedShieldConfig["value"]; exists && value != "" {
-			sharedShield["value"] = value.(string)
+	if includeAll || d.HasChange("shared_origin_shield") {
+		if v, ok := d.GetOk("shared_origin_shield"); ok {
+			sharedShieldConfig := v.([]interface{})[0].(map[string]interface{})
+			sharedShield := map[string]interface{}{
+				"enabled": sharedShieldConfig["enabled"].(bool),
+			}
+			if value, exists := sharedShieldConfig["value"]; exists && value != "" {
+				sharedShield["value"] = value.(string)
+			}
+			payload["sharedshield"] = sharedShield
+		} else {
+			payload["sharedshield"] = map[string]interface{}{"enabled": false}
 		}
+	}
 
-		payload := map[string]interface{}{
-			"sharedshield": sharedShield,
-		}
+	if includeAll || d.HasChange("hostname_pass_through") {
+		payload["edgetoorigin"] = d.Get("hostname_pass_through").(bool)
+	}
 
-		url := fmt.Sprintf("%s/api/2.6/services/%s/options", client.APIURL, d.Id())
-		resp, err := makeRequestWithRetry(client, "PUT", url, payload)
-		if err != nil {
-			return diag.Errorf("failed to configure SharedShield: %v", err)
+	if includeAll || d.HasChange("cors") {
+		payload["cors"] = d.Get("cors").(bool)
+	}
+
+	if includeAll || d.HasChange("auto_redirect") {
+		payload["autoRedirect"] = d.Get("auto_redirect").(bool)
+	}
+
+	return payload, nil
+}
+
+// reverseProxyOptions builds the reverseProxy options section. A missing reverse_proxy
+// block disables the reverse proxy.
+func reverseProxyOptions(d *schema.ResourceData, sendCredentials bool) (map[string]interface{}, error) {
+	v, ok := d.GetOk("reverse_proxy")
+	if !ok {
+		return map[string]interface{}{"enabled": false}, nil
+	}
+
+	reverseProxyConfig := v.([]interface{})[0].(map[string]interface{})
+	mode := reverseProxyConfig["mode"].(string)
+	reverseProxy := map[string]interface{}{
+		"enabled":           true, // Automatically enabled when the block is present
+		"hostname":          reverseProxyConfig["hostname"].(string),
+		"mode":              mode,
+		"ttl":               reverseProxyConfig["ttl"].(int),
+		"cacheByQueryParam": reverseProxyConfig["cache_by_query_param"].(bool),
+		"originScheme":      reverseProxyConfig["origin_scheme"].(string),
+		"useRobotsTxt":      reverseProxyConfig["use_robots_txt"].(bool),
+	}
+
+	if mode == "OBJECT_STORAGE" {
+		region := reverseProxyConfig["region"].(string)
+		if region == "" {
+			return nil, fmt.Errorf("region is required for OBJECT_STORAGE mode")
 		}
-		defer resp.Body.Close()
+		reverseProxy["region"] = region
 
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			return diag.Errorf("API error while configuring SharedShield: %s", string(body))
+		// Omitted credentials are left unchanged by CacheFly.
+		if sendCredentials {
+			accessKey, secretKey := reverseProxyCredentials(d)
+			if accessKey == "" || secretKey == "" {
+				return nil, fmt.Errorf("accessKey and secretKey are required for OBJECT_STORAGE mode")
+			}
+			reverseProxy["accessKey"] = accessKey
+			reverseProxy["secretKey"] = secretKey
 		}
 	}
 
-	if err := manageAdditionalConfigurations(client, d, createdService.ID); err != nil {
-		return diag.FromErr(err)
+	return reverseProxy, nil
+}
+
+// updateServiceOptions sends a PUT with the given payload to the service options endpoint.
+func updateServiceOptions(client *CacheFlyClient, serviceID string, payload map[string]interface{}) error {
+	url := fmt.Sprintf("%s/api/2.6/services/%s/options", client.APIURL, serviceID)
+	resp, err := makeRequestWithRetry(client, "PUT", url, payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API error: HTTP %d. Response: %s", resp.StatusCode, string(body))
 	}
 
-	return resourceCacheflyServiceRead(ctx, d, meta)
+	return nil
 }
 
 func resourceCacheflyServiceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*CacheFlyClient)
+	return readService(d, meta.(*CacheFlyClient), false)
+}
 
+// readService refreshes the state of a service. error_ttl and domains are only read back when
+// they are in the state already, so services whose domains are managed elsewhere show no drift.
+// On import the state is empty, so full reads them regardless.
+func readService(d *schema.ResourceData, client *CacheFlyClient, full bool) diag.Diagnostics {
 	service, err := fetchServiceDetails(client, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -442,10 +685,11 @@ func resourceCacheflyServiceRead(ctx context.Context, d *schema.ResourceData, me
 	d.Set("auto_ssl", service.AutoSsl)
 	d.Set("status", service.Status)
 
-	reverseProxy, errorTTL, hostnamePassThrough, sharedShield, err := getServiceOptions(client, d.Id())
+	options, err := getServiceOptions(client, d.Id())
 	if err != nil {
 		return diag.Errorf("failed to fetch service options: %v", err)
 	}
+	reverseProxy, errorTTL, sharedShield := options.ReverseProxy, options.ErrorTTL, options.SharedShield
 
 	if reverseProxy.Enabled {
 		reverseProxyMap := map[string]interface{}{
@@ -455,16 +699,25 @@ func resourceCacheflyServiceRead(ctx context.Context, d *schema.ResourceData, me
 			"ttl":                  reverseProxy.TTL,
 			"use_robots_txt":       reverseProxy.UseRobotsTxt,
 			"cache_by_query_param": reverseProxy.CacheByQueryParam,
-			"access_key":           reverseProxy.AccessKey,
-			"secret_key":           reverseProxy.SecretKey,
 			"region":               reverseProxy.Region,
+			// Credentials are never read back from the API, only what the configuration supplied is kept.
+			"access_key":         d.Get("reverse_proxy.0.access_key"),
+			"secret_key":         d.Get("reverse_proxy.0.secret_key"),
+			"secret_key_version": d.Get("reverse_proxy.0.secret_key_version"),
 		}
 		d.Set("reverse_proxy", []interface{}{reverseProxyMap})
+
+		if reverseProxy.Mode != "OBJECT_STORAGE" {
+			d.Set("credentials_hash", "")
+		} else if reverseProxy.AccessKey != "" || reverseProxy.SecretKey != "" {
+			d.Set("credentials_hash", hashCredentials(d.Get("unique_name").(string), reverseProxy.AccessKey, reverseProxy.SecretKey))
+		}
 	} else {
 		d.Set("reverse_proxy", nil)
+		d.Set("credentials_hash", "")
 	}
 
-	if _, ok := d.GetOk("error_ttl"); ok {
+	if _, ok := d.GetOk("error_ttl"); ok || (full && errorTTL != nil && errorTTL.Enabled) {
 		if errorTTL != nil {
 			errorTTLMap := map[string]interface{}{
 				"enabled": errorTTL.Enabled,
@@ -491,11 +744,85 @@ func resourceCacheflyServiceRead(ctx context.Context, d *schema.ResourceData, me
 		d.Set("shared_origin_shield", nil)
 	}
 
-	d.Set("hostname_pass_through", hostnamePassThrough)
+	d.Set("hostname_pass_through", options.HostnamePassThrough)
+	d.Set("cors", options.Cors)
+	d.Set("auto_redirect", options.AutoRedirect)
+
+	domains, err := fetchExistingDomains(client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("domain_certificates", flattenDomainCertificates(domains, service.AutoSsl)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if previous, ok := d.GetOk("domains"); ok || full {
+		previousDomains, _ := previous.([]interface{})
+		if err := d.Set("domains", flattenServiceDomains(domains, previousDomains, full)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	return nil
 }
 
+// flattenServiceDomains converts the custom domains of a service to the domains attribute,
+// keeping the order of the previous state and appending domains that are not in it. Default
+// cachefly.net domains are managed by CacheFly and left out. Certificates are only read back
+// for domains that configure them, or for every domain when withCertificates is set, since
+// omitting them in the configuration leaves the associations unchanged.
+func flattenServiceDomains(domains []DomainResource, previous []interface{}, withCertificates bool) []interface{} {
+	position := make(map[string]int, len(previous))
+	previousCertificates := make(map[string]bool, len(previous))
+	for i, item := range previous {
+		domain, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := domain["name"].(string)
+		position[name] = i
+		previousCertificates[name] = len(expandStringList(domain["certificates"])) > 0
+	}
+
+	custom := make([]DomainResource, 0, len(domains))
+	for _, domain := range domains {
+		if !isDefaultDomain(domain.Name) {
+			custom = append(custom, domain)
+		}
+	}
+	sort.SliceStable(custom, func(i, j int) bool {
+		pi, iKnown := position[custom[i].Name]
+		pj, jKnown := position[custom[j].Name]
+		if iKnown != jKnown {
+			return iKnown
+		}
+		return iKnown && pi < pj
+	})
+
+	result := make([]interface{}, 0, len(custom))
+	for _, domain := range custom {
+		validationMode := domain.ValidationMode
+		if validationMode == "" {
+			validationMode = "NONE"
+		}
+
+		certificates := []interface{}{}
+		if withCertificates || previousCertificates[domain.Name] {
+			for _, id := range domain.Certificates {
+				certificates = append(certificates, id)
+			}
+		}
+
+		result = append(result, map[string]interface{}{
+			"name":            domain.Name,
+			"description":     domain.Description,
+			"validation_mode": validationMode,
+			"certificates":    certificates,
+		})
+	}
+	return result
+}
+
 func fetchServiceDetails(client *CacheFlyClient, serviceID string) (*ServiceResource, error) {
 	url := fmt.Sprintf("%s/api/2.5/services/%s", client.APIURL, serviceID)
 	resp, err := makeRequestWithRetry(client, "GET", url, nil) // Use retry wrapper
@@ -523,190 +850,36 @@ func resourceCacheflyServiceUpdate(ctx context.Context, d *schema.ResourceData,
 	serviceID := d.Id()
 	var diags diag.Diagnostics
 
-	// Update description if it has changed
-	if d.HasChange("description") {
-		newDescription := d.Get("description").(string)
+	// Update name and description if they have changed
+	if d.HasChanges("name", "description") {
 		service := ServiceResource{
-			Description: newDescription,
+			Name:        d.Get("name").(string),
+			UniqueName:  d.Get("unique_name").(string),
+			Description: d.Get("description").(string),
 		}
 		err := updateServiceDetails(client, serviceID, &service)
 		if err != nil {
-			diags = append(diags, diag.Errorf("failed to update description: %v", err)...)
+			diags = append(diags, diag.Errorf("failed to update service details: %v", err)...)
 		}
 	}
 
-	// Fetch current service options
-	reverseProxy, _, _, _, err := getServiceOptions(client, serviceID)
-	if err != nil {
-		return diag.Errorf("failed to fetch service options: %v", err)
-	}
-
-	// Handle reverse proxy updates
-	if d.HasChange("reverse_proxy") {
-		if v, ok := d.GetOk("reverse_proxy"); ok {
-			reverseProxyConfig := v.([]interface{})[0].(map[string]interface{})
-			proxyConfig := ReverseProxy{
-				Enabled:           true, // Automatically enabled when the block is present
-				Hostname:          reverseProxyConfig["hostname"].(string),
-				Mode:              reverseProxyConfig["mode"].(string),
-				OriginScheme:      reverseProxyConfig["origin_scheme"].(string),
-				CacheByQueryParam: reverseProxyConfig["cache_by_query_param"].(bool),
-				TTL:               reverseProxyConfig["ttl"].(int),
-				UseRobotsTxt:      reverseProxyConfig["use_robots_txt"].(bool),
-			}
-
-			if reverseProxyConfig["prepend"] != nil {
-				proxyConfig.Prepend = reverseProxyConfig["prepend"].(string)
-			}
-			if reverseProxyConfig["access_key"] != nil {
-				proxyConfig.AccessKey = reverseProxyConfig["access_key"].(string)
-			}
-			if reverseProxyConfig["secret_key"] != nil {
-				proxyConfig.SecretKey = reverseProxyConfig["secret_key"].(string)
-			}
-			if reverseProxyConfig["region"] != nil {
-				proxyConfig.Region = reverseProxyConfig["region"].(string)
-			}
-			if reverseProxyConfig["bucket"] != nil {
-				proxyConfig.Bucket = reverseProxyConfig["bucket"].(string)
-			}
-
-			// Configure reverse proxy
-			err = configureReverseProxy(client, serviceID, proxyConfig)
-			if err != nil {
-				return diag.Errorf("failed to update reverse proxy: %v", err)
-			}
-		} else {
-			// If reverse proxy block is removed, disable it
-			if reverseProxy.Enabled {
-				err := configureReverseProxy(client, serviceID, ReverseProxy{Enabled: false})
-				if err != nil {
-					return diag.Errorf("failed to disable reverse proxy: %v", err)
-				}
-			}
-		}
-	}
-
-	// Handle error_ttl updates
-	if d.HasChange("error_ttl") {
-		if v, ok := d.GetOk("error_ttl"); ok {
-			errorTTLConfig := v.([]interface{})[0].(map[string]interface{})
-			errorTTL := map[string]interface{}{
-				"enabled": errorTTLConfig["enabled"].(bool),
-			}
-			if value, ok := errorTTLConfig["value"]; ok {
-				errorTTL["value"] = value.(int)
-			}
-
-			payload := map[string]interface{}{
-				"error_ttl": errorTTL,
-			}
-			url := fmt.Sprintf("%s/api/2.6/services/%s/options", client.APIURL, serviceID)
-			resp, err := makeRequestWithRetry(client, "PUT", url, payload)
-			if err != nil {
-				return diag.Errorf("failed to update error_ttl: %v", err)
-			}
-			defer resp.Body.Close()
-
-			if resp.StatusCode != http.StatusOK {
-				body, _ := io.ReadAll(resp.Body)
-				return diag.Errorf("API error while updating error_ttl: %s", string(body))
-			}
-		} else {
-			payload := map[string]interface{}{
-				"error_ttl": map[string]interface{}{
-					"enabled": false,
-				},
-			}
-			url := fmt.Sprintf("%s/api/2.6/services/%s/options", client.APIURL, serviceID)
-			resp, err := makeRequestWithRetry(client, "PUT", url, payload)
-			if err != nil {
-				return diag.Errorf("failed to disable error_ttl: %v", err)
-			}
-			defer resp.Body.Close()
-
-			if resp.StatusCode != http.StatusOK {
-				body, _ := io.ReadAll(resp.Body)
-				return diag.Errorf("API error while disabling error_ttl: %s", string(body))
-			}
-		}
-	}
-
-	if d.HasChange("hostname_pass_through") {
-		hostnamePassThrough := d.Get("hostname_pass_through").(bool)
-
-		payload := map[string]interface{}{
-			"edgetoorigin": hostnamePassThrough,
-		}
-
-		url := fmt.Sprintf("%s/api/2.6/services/%s/options", client.APIURL, serviceID)
-		resp, err := makeRequestWithRetry(client, "PUT", url, payload)
-		if err != nil {
-			return diag.Errorf("failed to update hostname_pass_through: %v", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			return diag.Errorf("API error while updating hostname_pass_through: %s", string(body))
-		}
+	if err := applyServiceOptions(client, d, serviceID, false); err != nil {
+		return append(diags, diag.FromErr(err)...)
 	}
 
 	// Manage domains if they have changed
 	if d.HasChange("domains") {
-		newDomains := d.Get("domains").([]interface{})
-		err := manageServiceDomains(client, serviceID, newDomains)
+		err := manageServiceDomains(client, serviceID, desiredServiceDomains(d))
 		if err != nil {
 			diags = append(diags, diag.Errorf("failed to update domains: %v", err)...)
 		}
 	}
 
-	if d.HasChange("shared_origin_shield") {
-		_, new := d.GetChange("shared_origin_shield")
-
-		// Handle removal of the block
-		if new == nil || len(new.([]interface{})) == 0 {
-			payload := map[string]interface{}{
-				"sharedshield": map[string]interface{}{
-					"enabled": false,
-				},
-			}
-
-			url := fmt.Sprintf("%s/api/2.6/services/%s/options", client.APIURL, d.Id())
-			resp, err := makeRequestWithRetry(client, "PUT", url, payload)
-			if err != nil {
-				return diag.Errorf("failed to disable shared_origin_shield: %v", err)
-			}
-			defer resp.Body.Close()
-
-			if resp.StatusCode != http.StatusOK {
-				body, _ := io.ReadAll(resp.Body)
-				return diag.Errorf("API error while disabling shared_origin_shield: %s", string(body))
-			}
-		} else if len(new.([]interface{})) > 0 {
-			// Handle updates to the block
-			sharedShieldConfig := new.([]interface{})[0].(map[string]interface{})
-			payload := map[string]interface{}{
-				"sharedshield": map[string]interface{}{
-					"enabled": sharedShieldConfig["enabled"].(bool),
-				},
-			}
-
-			if value, ok := sharedShieldConfig["value"]; ok {
-				payload["sharedshield"].(map[string]interface{})["value"] = value.(string)
-			}
-
-			url := fmt.Sprintf("%s/api/2.6/services/%s/options", client.APIURL, d.Id())
-			resp, err := makeRequestWithRetry(client, "PUT", url, payload)
-			if err != nil {
-				return diag.Errorf("failed to update shared_origin_shield: %v", err)
-			}
-			defer resp.Body.Close()
-
-			if resp.StatusCode != http.StatusOK {
-				body, _ := io.ReadAll(resp.Body)
-				return diag.Errorf("API error while updating shared_origin_shield: %s", string(body))
-			}
+	if d.HasChanges("auto_ssl", "domains") {
+		if err := applyAutoSsl(client, d, serviceID); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		} else {
+			diags = append(diags, waitForAutoSslCertificates(ctx, client, d, serviceID, schema.TimeoutUpdate)...)
 		}
 	}
 
@@ -753,34 +926,38 @@ func updateServiceDetails(client *CacheFlyClient, serviceID string, service *Ser
 func resourceCacheflyServiceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*CacheFlyClient)
 
-	if err := deactivateService(client, d.Id()); err != nil {
-		return diag.FromErr(err)
+	switch d.Get("deletion_policy").(string) {
+	case "abandon":
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  "Service abandoned",
+				Detail:   fmt.Sprintf("The service %s was removed from the Terraform state but left unchanged in CacheFly because deletion_policy is \"abandon\".", d.Id()),
+			},
+		}
+	case "delete":
+		if err := deleteService(client, d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
+	default:
+		if err := deactivateService(client, d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return nil
 }
 
 func findServiceByUniqueName(client *CacheFlyClient, uniqueName string) (*ServiceResource, error) {
-	url := fmt.Sprintf("%s/api/2.5/services?responseType=full", client.APIURL)
-	resp, err := makeRequestWithRetry(client, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch services after retries: %w", err)
-	}
-	defer resp.Body.Close()
+	query := url.Values{}
+	query.Set("responseType", "full")
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to fetch services: HTTP %d. Response: %s", resp.StatusCode, string(body))
-	}
-
-	var response struct {
-		Services []ServiceResource `json:"data"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode services response: %w", err)
+	services, err := fetchAllPages[ServiceResource](client, "/api/2.5/services", query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch services: %w", err)
 	}
 
-	for _, service := range response.Services {
+	for _, service := range services {
 		if service.UniqueName == uniqueName {
 			return &service, nil
 		}
@@ -806,135 +983,209 @@ func reactivateService(client *CacheFlyClient, serviceID string) error {
 	return nil
 }
 
-func configureReverseProxy(client *CacheFlyClient, serviceID string, reverseProxy ReverseProxy) error {
-	url := fmt.Sprintf("%s/api/2.6/services/%s/options", client.APIURL, serviceID)
-
-	// Fetching the current state of the reverse proxy
-	currentState, _, _, _, err := getServiceOptions(client, serviceID)
-	if err != nil {
-		return fmt.Errorf("failed to fetch current reverse proxy state: %w", err)
+// applyAutoSsl enables or disables AutoSSL as configured.
+func applyAutoSsl(client *CacheFlyClient, d *schema.ResourceData, serviceID string) error {
+	autoSsl, ok := d.GetOkExists("auto_ssl")
+	if !ok {
+		return nil
 	}
 
-	// Automatically enable reverse proxy if the configuration is provided
-	if reverseProxy.Hostname != "" {
-		reverseProxy.Enabled = true
+	if d.IsNewResource() || d.HasChange("auto_ssl") {
+		return setServiceAutoSsl(client, serviceID, autoSsl.(bool))
 	}
+	return nil
+}
 
-	if !currentState.Enabled && !reverseProxy.Enabled {
-		fmt.Println("Reverse proxy is already disabled. No action required.")
+// waitForAutoSslCertificates waits for the certificates of the validated domains when AutoSSL
+// is enabled and wait_for_certificates is set. Running out of time is only a warning: the
+// service is configured correctly and issuance carries on in the background.
+func waitForAutoSslCertificates(ctx context.Context, client *CacheFlyClient, d *schema.ResourceData, serviceID, timeoutKey string) diag.Diagnostics {
+	if !d.Get("auto_ssl").(bool) || !d.Get("wait_for_certificates").(bool) {
 		return nil
 	}
 
-	if !reverseProxy.Enabled {
-		payload := map[string]interface{}{
-			"reverseProxy": map[string]interface{}{
-				"enabled": false,
+	if err := waitForDomainCertificates(ctx, client, serviceID, d.Timeout(timeoutKey)); err != nil {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  "Certificates not issued yet",
+				Detail: fmt.Sprintf("Service %s is configured, but AutoSSL did not issue certificates for every validated "+
+					"domain in time: %v\n\nIssuance continues in the background and domain_certificates shows its "+
+					"progress after the next refresh. Increase the %s timeout to wait longer.", serviceID, err, timeoutKey),
 			},
 		}
+	}
+	return nil
+}
+
+// setServiceAutoSsl turns Let's Encrypt certificate provisioning on or off for a service.
+func setServiceAutoSsl(client *CacheFlyClient, serviceID string, enabled bool) error {
+	url := fmt.Sprintf("%s/api/2.5/services/%s", client.APIURL, serviceID)
+	resp, err := makeRequestWithRetry(client, "PUT", url, map[string]interface{}{"autoSsl": enabled})
+	if err != nil {
+		return fmt.Errorf("failed to update auto_ssl after retries: %w", err)
+	}
+	defer resp.Body.Close()
 
-		fmt.Printf("Disabling reverse proxy: %s\n", url)
-		resp, err := makeRequestWithRetry(client, "PUT", url, payload)
+	if err := handleResponse(resp); err != nil {
+		return fmt.Errorf("failed to update auto_ssl: %w", err)
+	}
+
+	return nil
+}
+
+// waitForDomainCertificates polls the domains of a service until every validated domain has a certificate.
+func waitForDomainCertificates(ctx context.Context, client *CacheFlyClient, serviceID string, timeout time.Duration) error {
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		domains, err := fetchExistingDomains(client, serviceID)
 		if err != nil {
-			return fmt.Errorf("failed to disable reverse proxy: %w", err)
+			return retry.NonRetryableError(err)
 		}
-		defer resp.Body.Close()
-
-		// Проверяем успешность ответа
-		body, _ := io.ReadAll(resp.Body)
-		fmt.Printf("Response Status: %d\n", resp.StatusCode)
-		fmt.Printf("Response Body: %s\n", string(body))
 
-		if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		var pending []string
+		for _, domain := range domains {
+			if domainCertificateStatus(domain, true) == "PENDING_ISSUANCE" {
+				pending = append(pending, domain.Name)
+			}
+		}
+		if len(pending) > 0 {
+			return retry.RetryableError(fmt.Errorf("waiting for certificates to be issued for: %s", strings.Join(pending, ", ")))
 		}
 		return nil
-	}
+	})
+}
 
-	payload := map[string]interface{}{
-		"reverseProxy": map[string]interface{}{
-			"enabled":           true,
-			"hostname":          reverseProxy.Hostname,
-			"mode":              reverseProxy.Mode,
-			"ttl":               reverseProxy.TTL,
-			"cacheByQueryParam": reverseProxy.CacheByQueryParam,
-			"originScheme":      reverseProxy.OriginScheme,
-			"useRobotsTxt":      reverseProxy.UseRobotsTxt,
-		},
+// domainCertificateStatus summarizes the certificate status of a domain. Without AutoSSL
+// nothing is pending, a domain without a certificate has none.
+func domainCertificateStatus(domain DomainResource, autoSsl bool) string {
+	switch {
+	case len(domain.Certificates) > 0:
+		return "ISSUED"
+	case !autoSsl:
+		return "NONE"
+	case domain.ValidationStatus == "VALIDATED" && !isDefaultDomain(domain.Name):
+		return "PENDING_ISSUANCE"
+	default:
+		return "PENDING_VALIDATION"
 	}
+}
 
-	if reverseProxy.Mode == "WEB" && reverseProxy.Prepend != "" {
-		payload["reverseProxy"].(map[string]interface{})["prepend"] = reverseProxy.Prepend
+func flattenDomainCertificates(domains []DomainResource, autoSsl bool) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(domains))
+	for _, domain := range domains {
+		result = append(result, map[string]interface{}{
+			"domain":            domain.Name,
+			"validation_status": domain.ValidationStatus,
+			"certificate_ids":   domain.Certificates,
+			"status":            domainCertificateStatus(domain, autoSsl),
+		})
 	}
+	return result
+}
 
-	if reverseProxy.Mode == "OBJECT_STORAGE" {
-		if reverseProxy.AccessKey == "" || reverseProxy.SecretKey == "" || reverseProxy.Region == "" {
-			return fmt.Errorf("accessKey, secretKey, and region are required for OBJECT_STORAGE mode")
+// desiredServiceDomains returns the configured domains for manageServiceDomains. The raw
+// configuration tells an omitted certificates list, which leaves the associations of the domain
+// unchanged and is returned as nil, from an explicit empty one, which detaches every certificate.
+func desiredServiceDomains(d *schema.ResourceData) []interface{} {
+	domains := d.Get("domains").([]interface{})
+	for i, item := range domains {
+		domain := item.(map[string]interface{})
+		if len(expandStringList(domain["certificates"])) > 0 {
+			continue
 		}
-		payload["reverseProxy"].(map[string]interface{})["accessKey"] = reverseProxy.AccessKey
-		payload["reverseProxy"].(map[string]interface{})["secretKey"] = reverseProxy.SecretKey
-		payload["reverseProxy"].(map[string]interface{})["region"] = reverseProxy.Region
-
-		if reverseProxy.Bucket != "" {
-			payload["reverseProxy"].(map[string]interface{})["bucket"] = reverseProxy.Bucket
+		v, diags := d.GetRawConfigAt(cty.GetAttrPath("domains").IndexInt(i).GetAttr("certificates"))
+		if diags.HasError() || v.IsNull() {
+			domain["certificates"] = nil
 		}
 	}
+	return domains
+}
 
-	fmt.Printf("Configuring reverse proxy: %s\n", url)
-	resp, err := makeRequestWithRetry(client, "PUT", url, payload)
-	if err != nil {
-		return fmt.Errorf("failed to configure reverse proxy: %w", err)
-	}
-	defer resp.Body.Close()
+// rawConfigReader is implemented by both schema.ResourceData and schema.ResourceDiff.
+type rawConfigReader interface {
+	GetRawConfigAt(valPath cty.Path) (cty.Value, diag.Diagnostics)
+}
 
-	body, _ := io.ReadAll(resp.Body)
-	fmt.Printf("Response Status: %d\n", resp.StatusCode)
-	fmt.Printf("Response Body: %s\n", string(body))
+// reverseProxyCredentials returns the object storage credentials from the raw configuration,
+// preferring the write-only attributes, which are never available through Get.
+func reverseProxyCredentials(d rawConfigReader) (accessKey, secretKey string) {
+	accessKey, _ = reverseProxyConfigString(d, "access_key_wo", "access_key")
+	secretKey, _ = reverseProxyConfigString(d, "secret_key_wo", "secret_key")
+	return accessKey, secretKey
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+// reverseProxyConfigString returns the first non-empty configured value among the given
+// reverse_proxy attributes. known is false if any of them is not known yet.
+func reverseProxyConfigString(d rawConfigReader, attrs ...string) (value string, known bool) {
+	for _, attr := range attrs {
+		v, diags := d.GetRawConfigAt(cty.GetAttrPath("reverse_proxy").IndexInt(0).GetAttr(attr))
+		if diags.HasError() || v.IsNull() {
+			continue
+		}
+		if !v.IsKnown() {
+			return "", false
+		}
+		if value == "" {
+			value = v.AsString()
+		}
 	}
+	return value, true
+}
 
-	return nil
+// hashCredentials returns the hash stored in credentials_hash for a pair of credentials. It is
+// an HMAC keyed on the service unique name, so equal credentials hash differently per service
+// and a hash cannot be matched against precomputed tables. Both parts are length-prefixed so
+// that a colon in a key cannot make two different pairs hash the same.
+func hashCredentials(uniqueName, accessKey, secretKey string) string {
+	mac := hmac.New(sha256.New, []byte(uniqueName))
+	fmt.Fprintf(mac, "%d:%s%d:%s", len(accessKey), accessKey, len(secretKey), secretKey)
+	return hex.EncodeToString(mac.Sum(nil))
 }
 
-func getServiceOptions(client *CacheFlyClient, serviceID string) (ReverseProxy, *ErrorTTL, bool, *SharedShield, error) {
+// getServiceOptions returns the options of a service. An error_ttl section without a value
+// is reported as absent.
+func getServiceOptions(client *CacheFlyClient, serviceID string) (*ServiceOptions, error) {
 	url := fmt.Sprintf("%s/api/2.6/services/%s/options", client.APIURL, serviceID)
 
 	resp, err := makeRequestWithRetry(client, "GET", url, nil)
 	if err != nil {
-		return ReverseProxy{}, nil, false, nil, fmt.Errorf("failed to fetch service options: %w", err)
+		return nil, fmt.Errorf("failed to fetch service options: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return ReverseProxy{}, nil, false, nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
 	}
 
 	var options ServiceOptions
 	if err := json.NewDecoder(resp.Body).Decode(&options); err != nil {
-		return ReverseProxy{}, nil, false, nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	var sharedShield *SharedShield
-	if options.SharedShield != nil {
-		sharedShield = &SharedShield{
-			Enabled: options.SharedShield.Enabled,
-			Value:   options.SharedShield.Value,
-		}
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	if options.ErrorTTL != nil && options.ErrorTTL.Value == nil {
 		options.ErrorTTL = nil
 	}
 
-	return options.ReverseProxy, options.ErrorTTL, options.HostnamePassThrough, sharedShield, nil
+	return &options, nil
 }
 
+// resourceCacheflyServiceImport imports a service by ID or, with a unique_name: prefix, by
+// its unique name, and reads its options and domains so the first plan after import is clean.
 func resourceCacheflyServiceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	client := meta.(*CacheFlyClient)
 
 	serviceID := d.Id()
+	if uniqueName, ok := strings.CutPrefix(serviceID, serviceImportUniqueNamePrefix); ok {
+		service, err := findServiceByUniqueName(client, uniqueName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up service for import: %w", err)
+		}
+		if service == nil {
+			return nil, fmt.Errorf("service with unique_name %s not found", uniqueName)
+		}
+		serviceID = service.ID
+	}
 
 	service, err := fetchServiceDetails(client, serviceID)
 	if err != nil {
@@ -945,11 +1196,23 @@ func resourceCacheflyServiceImport(ctx context.Context, d *schema.ResourceData,
 		return nil, fmt.Errorf("service with ID %s not found", serviceID)
 	}
 
-	d.Set("name", service.Name)
-	d.Set("unique_name", service.UniqueName)
-	d.Set("description", service.Description)
-	d.Set("auto_ssl", service.AutoSsl)
-	d.Set("status", service.Status)
+	d.SetId(service.ID)
+
+	// Arguments that only steer the provider are not stored by CacheFly, they start at their defaults.
+	d.Set("deletion_policy", "deactivate")
+	d.Set("reactivate_existing", true)
+	d.Set("wait_for_certificates", true)
+
+	if diags := readService(d, client, true); diags.HasError() {
+		for _, diagnostic := range diags {
+			if diagnostic.Severity == diag.Error {
+				return nil, fmt.Errorf("failed to read service for import: %s", diagnostic.Summary)
+			}
+		}
+	}
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// serviceImportUniqueNamePrefix marks an import ID that is a unique_name rather than a service ID.
+const serviceImportUniqueNamePrefix = "unique_name:"
@@ -0,0 +1,279 @@
+package cachefly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// AccessControl represents the geo and IP access control options of a service.
+type AccessControl struct {
+	Enabled        bool                   `json:"enabled"`
+	Countries      *AccessControlList     `json:"countries,omitempty"`
+	IPs            *AccessControlList     `json:"ips,omitempty"`
+	Referrers      *ReferrerAccessControl `json:"referrers,omitempty"`
+	DeniedResponse *DeniedResponse        `json:"deniedResponse,omitempty"`
+}
+
+type AccessControlList struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
+type ReferrerAccessControl struct {
+	Allow      []string `json:"allow"`
+	AllowEmpty bool     `json:"allowEmpty"`
+}
+
+type DeniedResponse struct {
+	StatusCode  int    `json:"statusCode"`
+	RedirectURL string `json:"redirectUrl,omitempty"`
+}
+
+var (
+	accessControlRules = []string{"allowed_countries", "denied_countries", "allowed_cidrs", "denied_cidrs", "allowed_referrers"}
+	referrerRegexp     = regexp.MustCompile(`^(\*\.)?[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`)
+)
+
+func resourceCacheflyServiceAccessControl() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCacheflyServiceAccessControlCreate,
+		ReadContext:   resourceCacheflyServiceAccessControlRead,
+		UpdateContext: resourceCacheflyServiceAccessControlUpdate,
+		DeleteContext: resourceCacheflyServiceAccessControlDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the service to restrict.",
+			},
+			"allowed_countries": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString, ValidateFunc: validateCountryCodeFunc},
+				ConflictsWith: []string{"denied_countries"},
+				AtLeastOneOf:  accessControlRules,
+				Description:   "ISO 3166-1 alpha-2 codes of the only countries allowed to access the service.",
+			},
+			"denied_countries": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString, ValidateFunc: validateCountryCodeFunc},
+				ConflictsWith: []string{"allowed_countries"},
+				AtLeastOneOf:  accessControlRules,
+				Description:   "ISO 3166-1 alpha-2 codes of countries denied access to the service.",
+			},
+			"allowed_cidrs": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString, ValidateFunc: validateCIDRNetwork},
+				AtLeastOneOf: accessControlRules,
+				Description:  "IPv4 or IPv6 networks in CIDR notation that are the only ones allowed to access the service.",
+			},
+			"denied_cidrs": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString, ValidateFunc: validateCIDRNetwork},
+				AtLeastOneOf: accessControlRules,
+				Description:  "IPv4 or IPv6 networks in CIDR notation that are denied access. Takes precedence over allowed_cidrs.",
+			},
+			"allowed_referrers": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(referrerRegexp, "must be a lowercase host name, optionally prefixed with '*.'"),
+				},
+				AtLeastOneOf: accessControlRules,
+				Description:  "Referrer host names allowed to access the service, e.g. example.com or *.example.com.",
+			},
+			"allow_empty_referrer": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether requests without a Referer header are allowed when allowed_referrers is set.",
+			},
+			"denied_status_code": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      403,
+				ValidateFunc: validation.IntBetween(400, 499),
+				Description:  "The status returned to denied requests. Ignored when redirect_url is set.",
+			},
+			"redirect_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "Redirect denied requests to this URL instead of returning denied_status_code.",
+			},
+		},
+	}
+}
+
+func resourceCacheflyServiceAccessControlCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+	serviceID := d.Get("service_id").(string)
+
+	if err := putAccessControl(client, serviceID, expandAccessControl(d)); err != nil {
+		return diag.Errorf("failed to configure access control: %v", err)
+	}
+
+	d.SetId(serviceID)
+
+	return resourceCacheflyServiceAccessControlRead(ctx, d, meta)
+}
+
+func resourceCacheflyServiceAccessControlRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	accessControl, err := fetchAccessControl(client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if accessControl == nil || !accessControl.Enabled {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("service_id", d.Id())
+
+	var countries, ips AccessControlList
+	if accessControl.Countries != nil {
+		countries = *accessControl.Countries
+	}
+	if accessControl.IPs != nil {
+		ips = *accessControl.IPs
+	}
+	d.Set("allowed_countries", countries.Allow)
+	d.Set("denied_countries", countries.Deny)
+	d.Set("allowed_cidrs", ips.Allow)
+	d.Set("denied_cidrs", ips.Deny)
+
+	if accessControl.Referrers != nil {
+		d.Set("allowed_referrers", accessControl.Referrers.Allow)
+		d.Set("allow_empty_referrer", accessControl.Referrers.AllowEmpty)
+	} else {
+		d.Set("allowed_referrers", nil)
+	}
+
+	if accessControl.DeniedResponse != nil {
+		d.Set("denied_status_code", accessControl.DeniedResponse.StatusCode)
+		d.Set("redirect_url", accessControl.DeniedResponse.RedirectURL)
+	}
+
+	return nil
+}
+
+func resourceCacheflyServiceAccessControlUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	if err := putAccessControl(client, d.Id(), expandAccessControl(d)); err != nil {
+		return diag.Errorf("failed to update access control: %v", err)
+	}
+
+	return resourceCacheflyServiceAccessControlRead(ctx, d, meta)
+}
+
+func resourceCacheflyServiceAccessControlDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	if err := putAccessControl(client, d.Id(), AccessControl{Enabled: false}); err != nil {
+		return diag.Errorf("failed to disable access control: %v", err)
+	}
+
+	return nil
+}
+
+// expandAccessControl always sends every list so that rules removed from the configuration are cleared.
+func expandAccessControl(d *schema.ResourceData) AccessControl {
+	return AccessControl{
+		Enabled: true,
+		Countries: &AccessControlList{
+			Allow: expandSortedSet(d.Get("allowed_countries")),
+			Deny:  expandSortedSet(d.Get("denied_countries")),
+		},
+		IPs: &AccessControlList{
+			Allow: expandSortedSet(d.Get("allowed_cidrs")),
+			Deny:  expandSortedSet(d.Get("denied_cidrs")),
+		},
+		Referrers: &ReferrerAccessControl{
+			Allow:      expandSortedSet(d.Get("allowed_referrers")),
+			AllowEmpty: d.Get("allow_empty_referrer").(bool),
+		},
+		DeniedResponse: &DeniedResponse{
+			StatusCode:  d.Get("denied_status_code").(int),
+			RedirectURL: d.Get("redirect_url").(string),
+		},
+	}
+}
+
+func expandSortedSet(v interface{}) []string {
+	values := make([]string, 0)
+	if set, ok := v.(*schema.Set); ok {
+		for _, item := range set.List() {
+			values = append(values, item.(string))
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// fetchAccessControl returns the access control options of a service, or nil if the service does not exist.
+func fetchAccessControl(client *CacheFlyClient, serviceID string) (*AccessControl, error) {
+	url := fmt.Sprintf("%s/api/2.6/services/%s/options", client.APIURL, serviceID)
+	resp, err := makeRequestWithRetry(client, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch service options after retries: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	} else if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to fetch service options: HTTP %d. Response: %s", resp.StatusCode, string(body))
+	}
+
+	var options struct {
+		AccessControl *AccessControl `json:"accessControl"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&options); err != nil {
+		return nil, fmt.Errorf("failed to decode service options: %w", err)
+	}
+
+	return options.AccessControl, nil
+}
+
+func putAccessControl(client *CacheFlyClient, serviceID string, accessControl AccessControl) error {
+	return updateServiceOptions(client, serviceID, map[string]interface{}{"accessControl": accessControl})
+}
+
+// validateCIDRNetwork checks that a value is a CIDR block without host bits set, e.g. 10.0.0.0/8 rather than 10.0.0.1/8.
+func validateCIDRNetwork(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	ip, network, err := net.ParseCIDR(v)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a network in CIDR notation, e.g. 203.0.113.0/24. Found: %s", key, v))
+		return
+	}
+	if !ip.Equal(network.IP) {
+		errs = append(errs, fmt.Errorf("%q has host bits set, use %s instead of %s", key, network.String(), v))
+	}
+	return
+}
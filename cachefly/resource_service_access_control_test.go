@@ -0,0 +1,101 @@
+package cachefly
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccServiceAccessControlResource = "cachefly_service_access_control.test"
+
+func TestAccCacheflyServiceAccessControl_basic(t *testing.T) {
+	srv := newTestServer(t)
+	service := srv.AddService(fakeapi.Service{Name: "Restricted", UniqueName: "restricted"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckAccessControlDisabled(srv, service.ID),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceAccessControlConfig(srv, service.ID, `
+  denied_cidrs = ["203.0.113.1/24"]
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`has host bits set`),
+			},
+			{
+				Config: testAccServiceAccessControlConfig(srv, service.ID, `
+  allowed_countries = ["US", "CA"]
+  denied_cidrs      = ["203.0.113.0/24"]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceAccessControlResource, "allowed_countries.#", "2"),
+					resource.TestCheckResourceAttr(testAccServiceAccessControlResource, "denied_status_code", "403"),
+					testAccCheckFakeAccessControl(srv, service.ID, func(accessControl AccessControl) error {
+						if fmt.Sprint(accessControl.Countries.Allow) != "[CA US]" || fmt.Sprint(accessControl.IPs.Deny) != "[203.0.113.0/24]" {
+							return fmt.Errorf("unexpected access control in the API: %+v", accessControl)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccServiceAccessControlConfig(srv, service.ID, `
+  allowed_referrers    = ["example.com", "*.example.com"]
+  allow_empty_referrer = false
+  redirect_url         = "https://example.com/denied"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceAccessControlResource, "allowed_countries.#", "0"),
+					resource.TestCheckResourceAttr(testAccServiceAccessControlResource, "allowed_referrers.#", "2"),
+					testAccCheckFakeAccessControl(srv, service.ID, func(accessControl AccessControl) error {
+						if len(accessControl.Countries.Allow) != 0 || len(accessControl.IPs.Deny) != 0 {
+							return fmt.Errorf("expected the removed rules to be cleared in the API, got %+v", accessControl)
+						}
+						if accessControl.Referrers.AllowEmpty || accessControl.DeniedResponse.RedirectURL != "https://example.com/denied" {
+							return fmt.Errorf("unexpected access control in the API: %+v", accessControl)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:      testAccServiceAccessControlResource,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccServiceAccessControlConfig(srv *fakeapi.Server, serviceID, body string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "cachefly_service_access_control" "test" {
+  service_id = %q
+%s}
+`, serviceID, body)
+}
+
+func testAccCheckFakeAccessControl(srv *fakeapi.Server, serviceID string, check func(AccessControl) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var accessControl AccessControl
+		if err := json.Unmarshal(srv.Options(serviceID)["accessControl"], &accessControl); err != nil {
+			return fmt.Errorf("failed to decode accessControl option: %w", err)
+		}
+		return check(accessControl)
+	}
+}
+
+func testAccCheckAccessControlDisabled(srv *fakeapi.Server, serviceID string) resource.TestCheckFunc {
+	return testAccCheckFakeAccessControl(srv, serviceID, func(accessControl AccessControl) error {
+		if accessControl.Enabled {
+			return fmt.Errorf("expected access control to be disabled on destroy")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,196 @@
+package cachefly
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	errorTTLMin = 1
+	errorTTLMax = 7776000
+)
+
+// sharedShieldRegionRegexp matches a POP code such as IAD. CacheFly does not publish the
+// POPs that can act as a shield and the API offers no endpoint listing them, so only the
+// shape is checked at plan time and the API rejects POPs it does not shield from.
+var sharedShieldRegionRegexp = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// domainNameRegexp matches lowercase domain names. The top-level label is either
+// alphabetic or an IDNA A-label (xn--...), as used by internationalized TLDs.
+var domainNameRegexp = regexp.MustCompile(`^(\*\.)?([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+([a-z]{2,63}|xn--[a-z0-9-]{1,59})$`)
+
+// resourceCacheflyServiceCustomizeDiff runs the cross-field checks that cannot be
+// expressed with per-attribute ValidateFunc, so invalid configurations fail at plan time.
+func resourceCacheflyServiceCustomizeDiff() schema.CustomizeDiffFunc {
+	return customdiff.All(
+		validateReverseProxyDiff,
+		validateErrorTTLDiff,
+		validateSharedShieldDiff,
+		validateDomainsDiff,
+		credentialsHashDiff,
+	)
+}
+
+// validateReverseProxyDiff checks that the reverse proxy fields match the selected mode.
+func validateReverseProxyDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if len(d.Get("reverse_proxy").([]interface{})) == 0 || !d.NewValueKnown("reverse_proxy.0.mode") {
+		return nil
+	}
+
+	if d.NewValueKnown("reverse_proxy.0.hostname") && d.Get("reverse_proxy.0.hostname").(string) == "" {
+		return fmt.Errorf("reverse_proxy.0.hostname is required when reverse_proxy is configured")
+	}
+
+	mode := d.Get("reverse_proxy.0.mode").(string)
+
+	switch mode {
+	case "OBJECT_STORAGE":
+		required := map[string][]string{
+			"access_key": {"access_key_wo", "access_key"},
+			"secret_key": {"secret_key_wo", "secret_key"},
+			"region":     {"region"},
+		}
+		var missing []string
+		for _, name := range []string{"access_key", "secret_key", "region"} {
+			if value, known := reverseProxyConfigString(d, required[name]...); known && value == "" {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("reverse_proxy: %s required when mode is OBJECT_STORAGE", strings.Join(missing, ", "))
+		}
+	case "WEB":
+		for _, attr := range []string{"access_key", "access_key_wo", "secret_key", "secret_key_wo", "region"} {
+			if v := reverseProxyConfigValue(d, attr); !v.IsNull() {
+				return fmt.Errorf("reverse_proxy.0.%s can only be set when mode is OBJECT_STORAGE", attr)
+			}
+		}
+	}
+
+	return nil
+}
+
+// credentialsHashDiff plans credentials_hash from the configured credentials. A hash that
+// differs from state, whether because the configuration changed or because Read detected
+// drift, produces a diff that resends the credentials.
+func credentialsHashDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	desired := ""
+	if len(d.Get("reverse_proxy").([]interface{})) > 0 {
+		if !d.NewValueKnown("reverse_proxy.0.mode") {
+			return d.SetNewComputed("credentials_hash")
+		}
+		if d.Get("reverse_proxy.0.mode").(string) == "OBJECT_STORAGE" {
+			accessKey, accessKnown := reverseProxyConfigString(d, "access_key_wo", "access_key")
+			secretKey, secretKnown := reverseProxyConfigString(d, "secret_key_wo", "secret_key")
+			if !accessKnown || !secretKnown || !d.NewValueKnown("unique_name") {
+				return d.SetNewComputed("credentials_hash")
+			}
+			desired = hashCredentials(d.Get("unique_name").(string), accessKey, secretKey)
+		}
+	}
+
+	if d.Get("credentials_hash").(string) == desired {
+		return nil
+	}
+	return d.SetNew("credentials_hash", desired)
+}
+
+// validateErrorTTLDiff checks that an enabled error_ttl carries a value within the accepted range.
+func validateErrorTTLDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if len(d.Get("error_ttl").([]interface{})) == 0 {
+		return nil
+	}
+	if !d.NewValueKnown("error_ttl.0.enabled") || !d.NewValueKnown("error_ttl.0.value") {
+		return nil
+	}
+
+	enabled := d.Get("error_ttl.0.enabled").(bool)
+	value := d.Get("error_ttl.0.value").(int)
+
+	if enabled && value == 0 {
+		return fmt.Errorf("error_ttl.0.value is required when error_ttl is enabled")
+	}
+	if value != 0 && (value < errorTTLMin || value > errorTTLMax) {
+		return fmt.Errorf("error_ttl.0.value must be between %d and %d seconds, got %d", errorTTLMin, errorTTLMax, value)
+	}
+
+	return nil
+}
+
+// validateSharedShieldDiff checks that an enabled Shared Origin Shield names a POP.
+func validateSharedShieldDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if len(d.Get("shared_origin_shield").([]interface{})) == 0 {
+		return nil
+	}
+	if !d.NewValueKnown("shared_origin_shield.0.enabled") || !d.NewValueKnown("shared_origin_shield.0.value") {
+		return nil
+	}
+
+	enabled := d.Get("shared_origin_shield.0.enabled").(bool)
+	value := d.Get("shared_origin_shield.0.value").(string)
+
+	if enabled && value == "" {
+		return fmt.Errorf("shared_origin_shield.0.value is required when the Shared Origin Shield is enabled")
+	}
+	if value != "" && !sharedShieldRegionRegexp.MatchString(value) {
+		return fmt.Errorf("shared_origin_shield.0.value %q is not a POP code, use the three letter code of the shield POP, e.g. IAD", value)
+	}
+
+	return nil
+}
+
+// validateDomainsDiff checks domain name syntax and rejects duplicates and CacheFly default domains.
+func validateDomainsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("domains") {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for i := range d.Get("domains").([]interface{}) {
+		key := fmt.Sprintf("domains.%d.name", i)
+		if !d.NewValueKnown(key) {
+			continue
+		}
+
+		name := d.Get(key).(string)
+		if err := validateDomainName(name); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		if seen[name] {
+			return fmt.Errorf("%s: domain %q is declared more than once", key, name)
+		}
+		seen[name] = true
+	}
+
+	return nil
+}
+
+// validateDomainName checks that name is a lowercase, optionally wildcard, fully qualified domain name.
+func validateDomainName(name string) error {
+	if name != strings.ToLower(name) {
+		return fmt.Errorf("domain %q must be lowercase", name)
+	}
+	if len(name) > 253 || !domainNameRegexp.MatchString(name) {
+		return fmt.Errorf("%q is not a valid domain name", name)
+	}
+	if isDefaultDomain(name) {
+		return fmt.Errorf("domain %q is a CacheFly default domain and is managed automatically", name)
+	}
+	return nil
+}
+
+// reverseProxyConfigValue returns the raw configuration value of a reverse_proxy attribute,
+// which distinguishes values set by the user from values computed from the API.
+func reverseProxyConfigValue(d *schema.ResourceDiff, attr string) cty.Value {
+	v, diags := d.GetRawConfigAt(cty.GetAttrPath("reverse_proxy").IndexInt(0).GetAttr(attr))
+	if diags.HasError() {
+		return cty.NullVal(cty.String)
+	}
+	return v
+}
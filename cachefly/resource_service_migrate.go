@@ -0,0 +1,139 @@
+package cachefly
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// State versions of cachefly_service.
+//
+// Every change to the shape of the state, such as renaming an attribute, turning a list into
+// a set or moving attributes between blocks, bumps SchemaVersion and adds a StateUpgrader from
+// the previous version, so existing states keep working without hand editing:
+//
+//   - the schema of the previous version is frozen in a resourceCacheflyServiceV<N> function
+//     and never changed afterwards;
+//   - the upgrader only rewrites what changed and keeps attributes it does not know about;
+//   - upgraders are never removed, Terraform runs them in order from the version in the state;
+//   - every upgrader has a test starting from a state as it was written by that version.
+//
+// Adding an optional attribute or a default does not change the shape of the state and needs
+// no new version.
+//
+// Version 0 is every state written before schema versioning was introduced. Arguments added
+// since the first release (deletion_policy, reactivate_existing, wait_for_certificates) are
+// missing from the oldest of these states, which made every service plan an in-place update,
+// and object storage services lack the credentials_hash that keeps credentials from being
+// sent again.
+
+// resourceCacheflyServiceV0 is the schema of the first release of cachefly_service. Attributes
+// added later without a version bump are absent, the upgrader keeps them when they are present.
+func resourceCacheflyServiceV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":        {Type: schema.TypeString, Required: true},
+			"unique_name": {Type: schema.TypeString, Required: true},
+			"description": {Type: schema.TypeString, Optional: true},
+			"auto_ssl":    {Type: schema.TypeBool, Optional: true, Computed: true},
+			"status":      {Type: schema.TypeString, Computed: true},
+			"domains": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name":            {Type: schema.TypeString, Required: true},
+						"description":     {Type: schema.TypeString, Optional: true},
+						"validation_mode": {Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+			"cors":          {Type: schema.TypeBool, Optional: true},
+			"auto_redirect": {Type: schema.TypeBool, Optional: true},
+			"reverse_proxy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hostname":             {Type: schema.TypeString, Optional: true},
+						"mode":                 {Type: schema.TypeString, Optional: true},
+						"ttl":                  {Type: schema.TypeInt, Optional: true},
+						"cache_by_query_param": {Type: schema.TypeBool, Optional: true},
+						"origin_scheme":        {Type: schema.TypeString, Optional: true},
+						"use_robots_txt":       {Type: schema.TypeBool, Optional: true},
+						"access_key":           {Type: schema.TypeString, Optional: true, Sensitive: true},
+						"secret_key":           {Type: schema.TypeString, Optional: true, Sensitive: true},
+						"region":               {Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+			"error_ttl": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {Type: schema.TypeBool, Optional: true, Computed: true},
+						"value":   {Type: schema.TypeInt, Optional: true, Computed: true},
+					},
+				},
+			},
+			"hostname_pass_through": {Type: schema.TypeBool, Optional: true},
+			"shared_origin_shield": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {Type: schema.TypeBool, Optional: true},
+						"value":   {Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceCacheflyServiceStateUpgradeV0 fills in the defaults of arguments added after the
+// first release, defaults the validation mode of domains and derives credentials_hash from
+// object storage credentials kept in the state.
+func resourceCacheflyServiceStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	setDefault(rawState, "deletion_policy", "deactivate")
+	setDefault(rawState, "reactivate_existing", true)
+	setDefault(rawState, "wait_for_certificates", true)
+	setDefault(rawState, "hostname_pass_through", false)
+
+	if domains, ok := rawState["domains"].([]interface{}); ok {
+		for _, item := range domains {
+			if domain, ok := item.(map[string]interface{}); ok {
+				setDefault(domain, "validation_mode", "NONE")
+			}
+		}
+	}
+
+	if hash, _ := rawState["credentials_hash"].(string); hash == "" {
+		if reverseProxies, ok := rawState["reverse_proxy"].([]interface{}); ok && len(reverseProxies) > 0 {
+			reverseProxy, _ := reverseProxies[0].(map[string]interface{})
+			accessKey, _ := reverseProxy["access_key"].(string)
+			secretKey, _ := reverseProxy["secret_key"].(string)
+			uniqueName, _ := rawState["unique_name"].(string)
+			if reverseProxy["mode"] == "OBJECT_STORAGE" && accessKey != "" && secretKey != "" {
+				rawState["credentials_hash"] = hashCredentials(uniqueName, accessKey, secretKey)
+			}
+		}
+	}
+
+	return rawState, nil
+}
+
+// setDefault sets key to value unless the state already holds a non-empty value for it.
+func setDefault(state map[string]interface{}, key string, value interface{}) {
+	if v, ok := state[key]; !ok || v == nil || v == "" {
+		state[key] = value
+	}
+}
@@ -0,0 +1,190 @@
+package cachefly
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// testServiceStateV0 is a cachefly_service state as written before schema versioning.
+func testServiceStateV0() map[string]interface{} {
+	return map[string]interface{}{
+		"id":          "svc-1",
+		"name":        "Example",
+		"unique_name": "example",
+		"description": "",
+		"auto_ssl":    false,
+		"status":      "ACTIVE",
+		"domains": []interface{}{
+			map[string]interface{}{"name": "www.example.com", "description": "", "validation_mode": ""},
+			map[string]interface{}{"name": "cdn.example.com", "description": "", "validation_mode": "HTTP"},
+		},
+		"cors":          false,
+		"auto_redirect": false,
+		"reverse_proxy": []interface{}{
+			map[string]interface{}{
+				"hostname":             "bucket.s3.amazonaws.com",
+				"mode":                 "OBJECT_STORAGE",
+				"ttl":                  float64(3600),
+				"cache_by_query_param": false,
+				"origin_scheme":        "HTTPS",
+				"use_robots_txt":       false,
+				"access_key":           "AKIA",
+				"secret_key":           "secret",
+				"region":               "us-east-1",
+			},
+		},
+		"error_ttl":             []interface{}{},
+		"shared_origin_shield":  []interface{}{},
+		"hostname_pass_through": false,
+	}
+}
+
+func TestResourceCacheflyServiceStateUpgradeV0(t *testing.T) {
+	testCases := []struct {
+		name     string
+		state    func() map[string]interface{}
+		expected func() map[string]interface{}
+	}{
+		{
+			name:  "first release",
+			state: testServiceStateV0,
+			expected: func() map[string]interface{} {
+				state := testServiceStateV0()
+				state["deletion_policy"] = "deactivate"
+				state["reactivate_existing"] = true
+				state["wait_for_certificates"] = true
+				state["credentials_hash"] = hashCredentials("example", "AKIA", "secret")
+				state["domains"].([]interface{})[0].(map[string]interface{})["validation_mode"] = "NONE"
+				return state
+			},
+		},
+		{
+			name: "later arguments kept",
+			state: func() map[string]interface{} {
+				state := testServiceStateV0()
+				state["deletion_policy"] = "delete"
+				state["reactivate_existing"] = false
+				state["wait_for_certificates"] = false
+				state["credentials_hash"] = "existing"
+				state["domains"] = []interface{}{
+					map[string]interface{}{"name": "www.example.com", "validation_mode": "DNS", "certificates": []interface{}{}},
+				}
+				return state
+			},
+			expected: func() map[string]interface{} {
+				state := testServiceStateV0()
+				state["deletion_policy"] = "delete"
+				state["reactivate_existing"] = false
+				state["wait_for_certificates"] = false
+				state["credentials_hash"] = "existing"
+				state["domains"] = []interface{}{
+					map[string]interface{}{"name": "www.example.com", "validation_mode": "DNS", "certificates": []interface{}{}},
+				}
+				return state
+			},
+		},
+		{
+			name: "standard origin",
+			state: func() map[string]interface{} {
+				state := testServiceStateV0()
+				delete(state, "hostname_pass_through")
+				reverseProxy := state["reverse_proxy"].([]interface{})[0].(map[string]interface{})
+				reverseProxy["mode"] = "WEB"
+				return state
+			},
+			expected: func() map[string]interface{} {
+				state := testServiceStateV0()
+				state["deletion_policy"] = "deactivate"
+				state["reactivate_existing"] = true
+				state["wait_for_certificates"] = true
+				state["domains"].([]interface{})[0].(map[string]interface{})["validation_mode"] = "NONE"
+				reverseProxy := state["reverse_proxy"].([]interface{})[0].(map[string]interface{})
+				reverseProxy["mode"] = "WEB"
+				return state
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := resourceCacheflyServiceStateUpgradeV0(context.Background(), tc.state(), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if expected := tc.expected(); !reflect.DeepEqual(actual, expected) {
+				t.Fatalf("unexpected state\n got: %#v\nwant: %#v", actual, expected)
+			}
+
+			again, err := resourceCacheflyServiceStateUpgradeV0(context.Background(), tc.expected(), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(again, tc.expected()) {
+				t.Fatalf("upgrade is not idempotent\n got: %#v\nwant: %#v", again, tc.expected())
+			}
+		})
+	}
+}
+
+func TestResourceCacheflyServiceStateUpgraders(t *testing.T) {
+	resource := resourceCacheflyService()
+	if len(resource.StateUpgraders) != resource.SchemaVersion {
+		t.Fatalf("expected an upgrader for each of the %d previous versions, got %d", resource.SchemaVersion, len(resource.StateUpgraders))
+	}
+	for i, upgrader := range resource.StateUpgraders {
+		if upgrader.Version != i {
+			t.Fatalf("expected upgrader %d to upgrade from version %d, got %d", i, i, upgrader.Version)
+		}
+	}
+
+	rawState, err := json.Marshal(testServiceStateV0())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := schema.NewGRPCProviderServer(Provider())
+	resp, err := server.UpgradeResourceState(context.Background(), &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: "cachefly_service",
+		Version:  0,
+		RawState: &tfprotov5.RawState{JSON: rawState},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	schemas, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := resp.UpgradedState.Unmarshal(schemas.ResourceSchemas["cachefly_service"].ValueType())
+	if err != nil {
+		t.Fatalf("upgraded state does not match the current schema: %s", err)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	for name, expected := range map[string]string{
+		"deletion_policy":  "deactivate",
+		"unique_name":      "example",
+		"credentials_hash": hashCredentials("example", "AKIA", "secret"),
+	} {
+		var actual string
+		if err := attributes[name].As(&actual); err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if actual != expected {
+			t.Fatalf("expected %s to be %q, got %q", name, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,532 @@
+package cachefly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Rule represents a single edge rule. Rules are evaluated in ascending priority.
+type Rule struct {
+	Name       string          `json:"name,omitempty"`
+	Enabled    bool            `json:"enabled"`
+	Priority   int             `json:"priority"`
+	Conditions []RuleCondition `json:"conditions"`
+	Actions    []RuleAction    `json:"actions"`
+}
+
+type RuleCondition struct {
+	Type     string   `json:"type"`
+	Operator string   `json:"operator"`
+	Name     string   `json:"name,omitempty"`
+	Values   []string `json:"values"`
+}
+
+type RuleAction struct {
+	Type        string `json:"type"`
+	HeaderName  string `json:"headerName,omitempty"`
+	HeaderValue string `json:"headerValue,omitempty"`
+	Target      string `json:"target,omitempty"`
+	StatusCode  int    `json:"statusCode,omitempty"`
+	TTL         *int   `json:"ttl,omitempty"`
+}
+
+type ServiceRules struct {
+	Rules []Rule `json:"rules"`
+}
+
+var (
+	ruleMatchTypes      = []string{"PATH", "EXTENSION", "HEADER", "QUERY", "COUNTRY"}
+	ruleMatchOperators  = []string{"EQUALS", "NOT_EQUALS", "PREFIX", "SUFFIX", "CONTAINS", "REGEX"}
+	ruleActionTypes     = []string{"SET_HEADER", "REDIRECT", "TTL_OVERRIDE", "DENY"}
+	redirectStatusCodes = []int{301, 302, 307, 308}
+)
+
+func resourceCacheflyServiceRules() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCacheflyServiceRulesCreate,
+		ReadContext:   resourceCacheflyServiceRulesRead,
+		UpdateContext: resourceCacheflyServiceRulesUpdate,
+		DeleteContext: resourceCacheflyServiceRulesDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: validateServiceRulesDiff,
+
+		Schema: map[string]*schema.Schema{
+			"service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the service the rules belong to.",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The ordered list of rules. Rules are evaluated in the order they are declared.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "A unique name describing the rule.",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether the rule is evaluated.",
+						},
+						"match": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "Conditions that must all match for the actions to apply.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(ruleMatchTypes, false),
+										Description:  "What the condition matches on: PATH, EXTENSION, HEADER, QUERY or COUNTRY.",
+									},
+									"operator": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "EQUALS",
+										ValidateFunc: validation.StringInSlice(ruleMatchOperators, false),
+										Description:  "How values are compared: EQUALS, NOT_EQUALS, PREFIX, SUFFIX, CONTAINS or REGEX.",
+									},
+									"name": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The header or query parameter name. Required for HEADER and QUERY conditions.",
+									},
+									"values": {
+										Type:        schema.TypeList,
+										Required:    true,
+										MinItems:    1,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "The values to match. The condition matches if any value matches.",
+									},
+								},
+							},
+						},
+						"action": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "Actions applied, in order, when the rule matches.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(ruleActionTypes, false),
+										Description:  "The action: SET_HEADER, REDIRECT, TTL_OVERRIDE or DENY.",
+									},
+									"header_name": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The response header to set. Required for SET_HEADER.",
+									},
+									"header_value": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The value of the response header. An empty value removes the header.",
+									},
+									"target": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+										Description:  "The redirect target URL. Required for REDIRECT.",
+									},
+									"status_code": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Description: "The response status. One of 301, 302, 307, 308 for REDIRECT (default 302), a 4xx status for DENY (default 403).",
+									},
+									"ttl": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntBetween(0, 7776000),
+										Description:  "The cache TTL in seconds. Required for TTL_OVERRIDE actions. 0 disables caching.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCacheflyServiceRulesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+	serviceID := d.Get("service_id").(string)
+
+	if err := putServiceRules(client, serviceID, expandServiceRules(d.Get("rule").([]interface{}))); err != nil {
+		return diag.Errorf("failed to create service rules: %v", err)
+	}
+
+	d.SetId(serviceID)
+
+	return resourceCacheflyServiceRulesRead(ctx, d, meta)
+}
+
+func resourceCacheflyServiceRulesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	rules, err := fetchServiceRules(client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if rules == nil {
+		d.SetId("")
+		return nil
+	}
+
+	// Default status codes are only written back where the configuration sets one, so an
+	// omitted status_code does not follow its rule around when rules are reordered.
+	configuredStatus := func(rule, action int) bool {
+		return d.Get(fmt.Sprintf("rule.%d.action.%d.status_code", rule, action)).(int) != 0
+	}
+
+	d.Set("service_id", d.Id())
+	if err := d.Set("rule", flattenServiceRules(rules, configuredStatus)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCacheflyServiceRulesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	if d.HasChange("rule") {
+		// The whole list is replaced so the order on the edge always matches the configuration.
+		if err := putServiceRules(client, d.Id(), expandServiceRules(d.Get("rule").([]interface{}))); err != nil {
+			return diag.Errorf("failed to update service rules: %v", err)
+		}
+	}
+
+	return resourceCacheflyServiceRulesRead(ctx, d, meta)
+}
+
+func resourceCacheflyServiceRulesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	if err := putServiceRules(client, d.Id(), []Rule{}); err != nil {
+		return diag.Errorf("failed to delete service rules: %v", err)
+	}
+
+	return nil
+}
+
+// fetchServiceRules returns the rules of a service sorted by priority, or nil if the service does not exist.
+func fetchServiceRules(client *CacheFlyClient, serviceID string) ([]Rule, error) {
+	url := fmt.Sprintf("%s/api/2.6/services/%s/rules", client.APIURL, serviceID)
+	resp, err := makeRequestWithRetry(client, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch service rules after retries: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	} else if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to fetch service rules: HTTP %d. Response: %s", resp.StatusCode, string(body))
+	}
+
+	var response ServiceRules
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode service rules response: %w", err)
+	}
+
+	rules := response.Rules
+	if rules == nil {
+		rules = []Rule{}
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Priority < rules[j].Priority })
+
+	return rules, nil
+}
+
+// putServiceRules replaces the complete rule list of a service.
+func putServiceRules(client *CacheFlyClient, serviceID string, rules []Rule) error {
+	url := fmt.Sprintf("%s/api/2.6/services/%s/rules", client.APIURL, serviceID)
+	resp, err := makeRequestWithRetry(client, "PUT", url, ServiceRules{Rules: rules})
+	if err != nil {
+		return fmt.Errorf("failed to send rules after retries: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return handleResponse(resp)
+}
+
+// expandServiceRules converts the rule blocks into API rules, using the position in the
+// list as the priority.
+func expandServiceRules(rawRules []interface{}) []Rule {
+	rules := make([]Rule, 0, len(rawRules))
+	for i, raw := range rawRules {
+		ruleMap := raw.(map[string]interface{})
+		rule := Rule{
+			Name:     ruleMap["name"].(string),
+			Enabled:  ruleMap["enabled"].(bool),
+			Priority: i,
+		}
+
+		for _, rawMatch := range ruleMap["match"].([]interface{}) {
+			match := rawMatch.(map[string]interface{})
+			rule.Conditions = append(rule.Conditions, RuleCondition{
+				Type:     match["type"].(string),
+				Operator: match["operator"].(string),
+				Name:     match["name"].(string),
+				Values:   expandStringList(match["values"]),
+			})
+		}
+
+		for _, rawAction := range ruleMap["action"].([]interface{}) {
+			action := rawAction.(map[string]interface{})
+			ruleAction := RuleAction{
+				Type:        action["type"].(string),
+				HeaderName:  action["header_name"].(string),
+				HeaderValue: action["header_value"].(string),
+				Target:      action["target"].(string),
+				StatusCode:  action["status_code"].(int),
+			}
+			if ruleAction.Type == "TTL_OVERRIDE" {
+				ttl := action["ttl"].(int)
+				ruleAction.TTL = &ttl
+			}
+			if ruleAction.StatusCode == 0 {
+				ruleAction.StatusCode = defaultRuleStatusCode(ruleAction.Type)
+			}
+			rule.Actions = append(rule.Actions, ruleAction)
+		}
+
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// defaultRuleStatusCode returns the status code sent for an action without status_code.
+func defaultRuleStatusCode(actionType string) int {
+	switch actionType {
+	case "REDIRECT":
+		return http.StatusFound
+	case "DENY":
+		return http.StatusForbidden
+	}
+	return 0
+}
+
+// flattenServiceRules converts API rules into rule blocks. A status code equal to the
+// default of its action type is left out unless configuredStatus reports that the
+// configuration sets it.
+func flattenServiceRules(rules []Rule, configuredStatus func(rule, action int) bool) []interface{} {
+	result := make([]interface{}, 0, len(rules))
+	for i, rule := range rules {
+		matches := make([]interface{}, 0, len(rule.Conditions))
+		for _, condition := range rule.Conditions {
+			matches = append(matches, map[string]interface{}{
+				"type":     condition.Type,
+				"operator": condition.Operator,
+				"name":     condition.Name,
+				"values":   condition.Values,
+			})
+		}
+
+		actions := make([]interface{}, 0, len(rule.Actions))
+		for j, action := range rule.Actions {
+			statusCode := action.StatusCode
+			if statusCode == defaultRuleStatusCode(action.Type) && !configuredStatus(i, j) {
+				statusCode = 0
+			}
+			actionMap := map[string]interface{}{
+				"type":         action.Type,
+				"header_name":  action.HeaderName,
+				"header_value": action.HeaderValue,
+				"target":       action.Target,
+				"status_code":  statusCode,
+			}
+			if action.TTL != nil {
+				actionMap["ttl"] = *action.TTL
+			}
+			actions = append(actions, actionMap)
+		}
+
+		result = append(result, map[string]interface{}{
+			"name":    rule.Name,
+			"enabled": rule.Enabled,
+			"match":   matches,
+			"action":  actions,
+		})
+	}
+	return result
+}
+
+// validateServiceRulesDiff checks that every condition and action carries the fields its type needs.
+func validateServiceRulesDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("rule") {
+		return nil
+	}
+
+	names := make(map[string]int)
+	for i, rawRule := range d.Get("rule").([]interface{}) {
+		ruleMap, ok := rawRule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		prefix := fmt.Sprintf("rule.%d", i)
+
+		if name := ruleMap["name"].(string); name != "" {
+			if j, exists := names[name]; exists {
+				return fmt.Errorf("%s: rule name %q is already used by rule.%d", prefix, name, j)
+			}
+			names[name] = i
+		}
+
+		for j, rawMatch := range ruleMap["match"].([]interface{}) {
+			key := fmt.Sprintf("%s.match.%d", prefix, j)
+			if err := validateRuleCondition(rawMatch.(map[string]interface{}), knownFields(d, key)); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+		}
+
+		actions := ruleMap["action"].([]interface{})
+		for j, rawAction := range actions {
+			key := fmt.Sprintf("%s.action.%d", prefix, j)
+			action := rawAction.(map[string]interface{})
+			if action["type"].(string) == "DENY" && len(actions) > 1 {
+				return fmt.Errorf("%s: a DENY action cannot be combined with other actions", key)
+			}
+			if err := validateRuleAction(action, knownFields(d, key), configuredActionFields(d, i, j)); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// knownFields returns a function reporting whether a field below prefix is known at plan time,
+// so required-field checks are skipped for values that come from other resources.
+func knownFields(d *schema.ResourceDiff, prefix string) func(field string) bool {
+	return func(field string) bool {
+		return d.NewValueKnown(prefix + "." + field)
+	}
+}
+
+// configuredActionFields returns a function reporting whether a field of an action is set in
+// the configuration, and whether that is known yet. Defaults such as a ttl of 0 cannot tell
+// an omitted field from one set to its zero value.
+func configuredActionFields(d rawConfigReader, rule, action int) func(field string) (set, known bool) {
+	return func(field string) (bool, bool) {
+		v, diags := d.GetRawConfigAt(cty.GetAttrPath("rule").IndexInt(rule).GetAttr("action").IndexInt(action).GetAttr(field))
+		if diags.HasError() || !v.IsWhollyKnown() {
+			return false, false
+		}
+		return !v.IsNull(), true
+	}
+}
+
+func validateRuleCondition(match map[string]interface{}, known func(string) bool) error {
+	if !known("type") || !known("operator") || !known("values") {
+		return nil
+	}
+	matchType := match["type"].(string)
+	operator := match["operator"].(string)
+	values := expandStringList(match["values"])
+
+	switch matchType {
+	case "HEADER", "QUERY":
+		if known("name") && match["name"].(string) == "" {
+			return fmt.Errorf("name is required for %s conditions", matchType)
+		}
+	default:
+		if match["name"].(string) != "" {
+			return fmt.Errorf("name can only be set for HEADER and QUERY conditions")
+		}
+	}
+
+	for _, value := range values {
+		if operator == "REGEX" {
+			if _, err := regexp.Compile(value); err != nil {
+				return fmt.Errorf("invalid regular expression %q: %v", value, err)
+			}
+			continue
+		}
+
+		switch matchType {
+		case "PATH":
+			if operator != "CONTAINS" && operator != "SUFFIX" && !strings.HasPrefix(value, "/") {
+				return fmt.Errorf("PATH value %q must start with '/'", value)
+			}
+		case "EXTENSION":
+			if strings.HasPrefix(value, ".") || strings.Contains(value, "/") {
+				return fmt.Errorf("EXTENSION value %q must be an extension without the leading dot, e.g. jpg", value)
+			}
+		case "COUNTRY":
+			if err := validateCountryCode(value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateRuleAction(action map[string]interface{}, known func(string) bool, configured func(string) (set, known bool)) error {
+	if !known("type") {
+		return nil
+	}
+	statusCode := action["status_code"].(int)
+	actionType := action["type"].(string)
+	ttlSet, ttlKnown := configured("ttl")
+
+	if actionType != "TTL_OVERRIDE" && ttlKnown && ttlSet {
+		return fmt.Errorf("ttl can only be set for TTL_OVERRIDE actions")
+	}
+
+	switch actionType {
+	case "TTL_OVERRIDE":
+		if ttlKnown && !ttlSet {
+			return fmt.Errorf("ttl is required for TTL_OVERRIDE actions")
+		}
+	case "SET_HEADER":
+		if known("header_name") && action["header_name"].(string) == "" {
+			return fmt.Errorf("header_name is required for SET_HEADER actions")
+		}
+	case "REDIRECT":
+		if known("target") && action["target"].(string) == "" {
+			return fmt.Errorf("target is required for REDIRECT actions")
+		}
+		if statusCode != 0 && !slices.Contains(redirectStatusCodes, statusCode) {
+			return fmt.Errorf("status_code must be one of 301, 302, 307 or 308 for REDIRECT actions, got %d", statusCode)
+		}
+	case "DENY":
+		if statusCode != 0 && (statusCode < 400 || statusCode > 499) {
+			return fmt.Errorf("status_code must be a 4xx status for DENY actions, got %d", statusCode)
+		}
+	}
+
+	return nil
+}
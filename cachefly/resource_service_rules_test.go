@@ -0,0 +1,252 @@
+package cachefly
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccServiceRulesResource = "cachefly_service_rules.test"
+
+const testAccRuleLegacyRedirect = `
+  rule {
+    name = "legacy"
+    match {
+      type     = "PATH"
+      operator = "PREFIX"
+      values   = ["/old"]
+    }
+    action {
+      type   = "REDIRECT"
+      target = "https://example.com/new"
+    }
+  }
+`
+
+const testAccRuleBlockAdmin = `
+  rule {
+    name = "block"
+    match {
+      type     = "PATH"
+      operator = "PREFIX"
+      values   = ["/admin"]
+    }
+    action {
+      type = "DENY"
+    }
+  }
+`
+
+const testAccRuleNoCache = `
+  rule {
+    name = "no-cache"
+    match {
+      type   = "EXTENSION"
+      values = ["json"]
+    }
+    action {
+      type = "TTL_OVERRIDE"
+      ttl  = 0
+    }
+  }
+`
+
+func TestAccCacheflyServiceRules_basic(t *testing.T) {
+	srv := newTestServer(t)
+	service := srv.AddService(fakeapi.Service{Name: "Rules", UniqueName: "rules"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckServiceRulesEmpty(srv, service.ID),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceRulesConfig(srv, service.ID, testAccRuleLegacyRedirect+testAccRuleBlockAdmin+testAccRuleNoCache),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceRulesResource, "rule.#", "3"),
+					resource.TestCheckResourceAttr(testAccServiceRulesResource, "rule.0.action.0.status_code", "0"),
+					resource.TestCheckResourceAttr(testAccServiceRulesResource, "rule.1.action.0.status_code", "0"),
+					resource.TestCheckResourceAttr(testAccServiceRulesResource, "rule.2.action.0.ttl", "0"),
+					testAccCheckFakeRuleActions(srv, service.ID, "legacy:REDIRECT/302", "block:DENY/403", "no-cache:TTL_OVERRIDE/0/ttl=0"),
+				),
+			},
+			{
+				// Swapping rules must not carry a status code over from the rule previously
+				// at the same position.
+				Config: testAccServiceRulesConfig(srv, service.ID, testAccRuleBlockAdmin+testAccRuleLegacyRedirect+testAccRuleNoCache),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceRulesResource, "rule.0.name", "block"),
+					resource.TestCheckResourceAttr(testAccServiceRulesResource, "rule.0.action.0.status_code", "0"),
+					testAccCheckFakeRuleActions(srv, service.ID, "block:DENY/403", "legacy:REDIRECT/302", "no-cache:TTL_OVERRIDE/0/ttl=0"),
+				),
+			},
+			{
+				Config: testAccServiceRulesConfig(srv, service.ID, testAccRuleBlockAdmin+`
+  rule {
+    name = "legacy"
+    match {
+      type     = "PATH"
+      operator = "PREFIX"
+      values   = ["/old"]
+    }
+    action {
+      type = "DENY"
+    }
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceRulesResource, "rule.#", "2"),
+					testAccCheckFakeRuleActions(srv, service.ID, "block:DENY/403", "legacy:DENY/403"),
+				),
+			},
+			{
+				Config: testAccServiceRulesConfig(srv, service.ID, `
+  rule {
+    name = "legacy"
+    match {
+      type     = "PATH"
+      operator = "PREFIX"
+      values   = ["/old"]
+    }
+    action {
+      type        = "REDIRECT"
+      target      = "https://example.com/new"
+      status_code = 301
+    }
+    action {
+      type         = "SET_HEADER"
+      header_name  = "X-Moved"
+      header_value = "1"
+    }
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceRulesResource, "rule.0.action.0.status_code", "301"),
+					resource.TestCheckResourceAttr(testAccServiceRulesResource, "rule.0.action.1.header_name", "X-Moved"),
+					testAccCheckFakeRuleActions(srv, service.ID, "legacy:REDIRECT/301,SET_HEADER/0"),
+				),
+			},
+			{
+				ResourceName:      testAccServiceRulesResource,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccCacheflyServiceRules_validation(t *testing.T) {
+	srv := newTestServer(t)
+
+	tests := map[string]struct {
+		rule     string
+		expected string
+	}{
+		"ttl override without ttl": {
+			rule: `
+  rule {
+    match {
+      type   = "EXTENSION"
+      values = ["json"]
+    }
+    action {
+      type = "TTL_OVERRIDE"
+    }
+  }
+`,
+			expected: `ttl is required for TTL_OVERRIDE actions`,
+		},
+		"ttl on another action": {
+			rule: `
+  rule {
+    match {
+      type   = "EXTENSION"
+      values = ["json"]
+    }
+    action {
+      type        = "SET_HEADER"
+      header_name = "X-Cache"
+      ttl         = 0
+    }
+  }
+`,
+			expected: `ttl can only be set for TTL_OVERRIDE actions`,
+		},
+		"redirect status": {
+			rule: `
+  rule {
+    match {
+      type   = "PATH"
+      values = ["/old"]
+    }
+    action {
+      type        = "REDIRECT"
+      target      = "https://example.com/"
+      status_code = 404
+    }
+  }
+`,
+			expected: `status_code must be one of 301, 302, 307 or 308`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccServiceRulesConfig(srv, "service-id", tc.rule),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(regexp.QuoteMeta(tc.expected)),
+					},
+				},
+			})
+		})
+	}
+}
+
+func testAccServiceRulesConfig(srv *fakeapi.Server, serviceID, rules string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "cachefly_service_rules" "test" {
+  service_id = %q
+%s}
+`, serviceID, rules)
+}
+
+// testAccCheckFakeRuleActions compares the rules stored by the API with a compact
+// description: name:TYPE/status per action, with /ttl=N for actions that send a ttl.
+func testAccCheckFakeRuleActions(srv *fakeapi.Server, serviceID string, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var got []string
+		for _, rule := range srv.Rules(serviceID) {
+			description := rule.Name + ":"
+			for i, action := range rule.Actions {
+				if i > 0 {
+					description += ","
+				}
+				description += fmt.Sprintf("%s/%d", action.Type, action.StatusCode)
+				if action.TTL != nil {
+					description += fmt.Sprintf("/ttl=%d", *action.TTL)
+				}
+			}
+			got = append(got, description)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(expected) {
+			return fmt.Errorf("expected rules %v in the API, got %v", expected, got)
+		}
+		return nil
+	}
+}
+
+func testAccCheckServiceRulesEmpty(srv *fakeapi.Server, serviceID string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if rules := srv.Rules(serviceID); len(rules) != 0 {
+			return fmt.Errorf("expected the rules to be removed on destroy, got %d", len(rules))
+		}
+		return nil
+	}
+}
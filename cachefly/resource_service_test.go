@@ -0,0 +1,989 @@
+package cachefly
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccServiceResource = "cachefly_service.test"
+
+// Attributes that only exist in the configuration and cannot be recovered on import.
+var testAccServiceImportIgnore = []string{
+	"reverse_proxy.0.access_key",
+	"reverse_proxy.0.secret_key",
+}
+
+func TestAccCacheflyService_basic(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckServiceDeactivated(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Example"
+  unique_name = "example"
+  description = "first"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceResource, "name", "Example"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "unique_name", "example"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "description", "first"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "status", "ACTIVE"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "hostname_pass_through", "false"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "domain_certificates.#", "1"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "domain_certificates.0.domain", "example.cachefly.net"),
+					testAccCheckFakeService(srv, func(service fakeapi.Service) error {
+						if service.Description != "first" {
+							return fmt.Errorf("expected description %q in the API, got %q", "first", service.Description)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccServiceConfig(srv, `
+  name                  = "Example renamed"
+  unique_name           = "example"
+  description           = "second"
+  hostname_pass_through = true
+  cors                  = true
+  auto_redirect         = true
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceResource, "name", "Example renamed"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "description", "second"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "hostname_pass_through", "true"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "cors", "true"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "auto_redirect", "true"),
+					testAccCheckFakeService(srv, func(service fakeapi.Service) error {
+						if service.Name != "Example renamed" {
+							return fmt.Errorf("expected name %q in the API, got %q", "Example renamed", service.Name)
+						}
+						return nil
+					}),
+					testAccCheckFakeOption(srv, "edgetoorigin", `true`),
+					testAccCheckFakeOption(srv, "cors", `true`),
+					testAccCheckFakeOption(srv, "autoRedirect", `true`),
+				),
+			},
+			{
+				ResourceName:            testAccServiceResource,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: testAccServiceImportIgnore,
+			},
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Example renamed"
+  unique_name = "example"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceResource, "description", ""),
+					resource.TestCheckResourceAttr(testAccServiceResource, "hostname_pass_through", "false"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "cors", "false"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "auto_redirect", "false"),
+					testAccCheckFakeOption(srv, "edgetoorigin", `false`),
+					testAccCheckFakeOption(srv, "cors", `false`),
+					testAccCheckFakeOption(srv, "autoRedirect", `false`),
+				),
+			},
+			{
+				ResourceName:            testAccServiceResource,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: testAccServiceImportIgnore,
+			},
+		},
+	})
+}
+
+func TestAccCacheflyService_reverseProxy(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckServiceDeactivated(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Proxy"
+  unique_name = "proxy"
+
+  reverse_proxy {
+    hostname = "origin.example.com"
+    ttl      = 3600
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceResource, "reverse_proxy.0.mode", "WEB"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "reverse_proxy.0.hostname", "origin.example.com"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "reverse_proxy.0.ttl", "3600"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "credentials_hash", ""),
+					testAccCheckFakeReverseProxy(srv, func(rp ReverseProxy) error {
+						if !rp.Enabled || rp.Mode != "WEB" || rp.Hostname != "origin.example.com" {
+							return fmt.Errorf("unexpected reverse proxy in the API: %+v", rp)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Proxy"
+  unique_name = "proxy"
+
+  reverse_proxy {
+    hostname      = "bucket.s3.amazonaws.com"
+    mode          = "OBJECT_STORAGE"
+    origin_scheme = "HTTPS"
+    region        = "us-east-1"
+    access_key    = "AKIAEXAMPLE"
+    secret_key    = "secret-example"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceResource, "reverse_proxy.0.mode", "OBJECT_STORAGE"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "reverse_proxy.0.region", "us-east-1"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "credentials_hash", hashCredentials("proxy", "AKIAEXAMPLE", "secret-example")),
+					testAccCheckFakeReverseProxy(srv, func(rp ReverseProxy) error {
+						if rp.Mode != "OBJECT_STORAGE" || rp.AccessKey != "AKIAEXAMPLE" || rp.SecretKey != "secret-example" {
+							return fmt.Errorf("unexpected reverse proxy in the API: %+v", rp)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Proxy"
+  unique_name = "proxy"
+
+  reverse_proxy {
+    hostname = "origin.example.com"
+    mode     = "WEB"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceResource, "reverse_proxy.0.mode", "WEB"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "credentials_hash", ""),
+				),
+			},
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Proxy"
+  unique_name = "proxy"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceResource, "reverse_proxy.#", "0"),
+					testAccCheckFakeReverseProxy(srv, func(rp ReverseProxy) error {
+						if rp.Enabled {
+							return fmt.Errorf("expected the reverse proxy to be disabled in the API: %+v", rp)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCacheflyService_errorTTLAndSharedShield(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckServiceDeactivated(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Shield"
+  unique_name = "shield"
+
+  error_ttl {
+    enabled = true
+    value   = 60
+  }
+
+  shared_origin_shield {
+    enabled = true
+    value   = "IAD"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceResource, "error_ttl.0.enabled", "true"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "error_ttl.0.value", "60"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "shared_origin_shield.0.value", "IAD"),
+					testAccCheckFakeOption(srv, "error_ttl", `{"enabled":true,"value":60}`),
+					testAccCheckFakeOption(srv, "sharedshield", `{"enabled":true,"value":"IAD"}`),
+				),
+			},
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Shield"
+  unique_name = "shield"
+
+  error_ttl {
+    enabled = true
+    value   = 300
+  }
+
+  shared_origin_shield {
+    enabled = true
+    value   = "FRA"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceResource, "error_ttl.0.value", "300"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "shared_origin_shield.0.value", "FRA"),
+					testAccCheckFakeOption(srv, "sharedshield", `{"enabled":true,"value":"FRA"}`),
+				),
+			},
+			{
+				ResourceName:            testAccServiceResource,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: testAccServiceImportIgnore,
+			},
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Shield"
+  unique_name = "shield"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceResource, "error_ttl.#", "0"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "shared_origin_shield.#", "0"),
+					testAccCheckFakeOption(srv, "error_ttl", `{"enabled":false}`),
+					testAccCheckFakeOption(srv, "sharedshield", `{"enabled":false}`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCacheflyService_planValidation(t *testing.T) {
+	srv := newTestServer(t)
+
+	tests := map[string]struct {
+		body     string
+		expected string
+	}{
+		"object storage without credentials": {
+			body: `
+  reverse_proxy {
+    hostname = "bucket.s3.amazonaws.com"
+    mode     = "OBJECT_STORAGE"
+    region   = "us-east-1"
+  }
+`,
+			expected: `reverse_proxy: access_key, secret_key required when mode is OBJECT_STORAGE`,
+		},
+		"web with credentials": {
+			body: `
+  reverse_proxy {
+    hostname   = "origin.example.com"
+    access_key = "AKIAEXAMPLE"
+  }
+`,
+			expected: `reverse_proxy.0.access_key can only be set when mode is OBJECT_STORAGE`,
+		},
+		"error ttl out of range": {
+			body: `
+  error_ttl {
+    enabled = true
+    value   = 7776001
+  }
+`,
+			expected: `error_ttl.0.value must be between 1 and 7776000 seconds, got 7776001`,
+		},
+		"unknown shield region": {
+			body: `
+  shared_origin_shield {
+    enabled = true
+    value   = "us-east-1"
+  }
+`,
+			expected: `shared_origin_shield.0.value "us-east-1" is not a POP code`,
+		},
+		"duplicate domain": {
+			body: `
+  domains {
+    name = "cdn.example.com"
+  }
+
+  domains {
+    name = "cdn.example.com"
+  }
+`,
+			expected: `domains.1.name: domain "cdn.example.com" is declared more than once`,
+		},
+		"uppercase domain": {
+			body: `
+  domains {
+    name = "CDN.example.com"
+  }
+`,
+			expected: `domain "CDN.example.com" must be lowercase`,
+		},
+		"default domain": {
+			body: `
+  domains {
+    name = "validation.cachefly.net"
+  }
+`,
+			expected: `domain "validation.cachefly.net" is a CacheFly default domain`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccServiceConfig(srv, `
+  name        = "Validation"
+  unique_name = "validation"
+`+tc.body),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(regexp.QuoteMeta(tc.expected)),
+					},
+				},
+			})
+		})
+	}
+}
+
+// Values that are only known after apply must not fail the plan-time checks.
+func TestAccCacheflyService_planValidationUnknownValues(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Unknown"
+  unique_name = "unknown"
+
+  reverse_proxy {
+    hostname   = "bucket.s3.amazonaws.com"
+    mode       = "OBJECT_STORAGE"
+    region     = "us-east-1"
+    access_key = terraform_data.generated.id
+    secret_key = terraform_data.generated.id
+  }
+
+  error_ttl {
+    enabled = true
+    value   = length(terraform_data.generated.id)
+  }
+
+  shared_origin_shield {
+    enabled = true
+    value   = terraform_data.generated.id
+  }
+
+  domains {
+    name = "${terraform_data.generated.id}.example.com"
+  }
+
+  domains {
+    name = "${terraform_data.generated.id}.example.com"
+  }
+`) + `
+resource "terraform_data" "generated" {
+  input = "generated"
+}
+`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccCacheflyService_domains(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckServiceDeactivated(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Domains"
+  unique_name = "domains"
+
+  domains {
+    name        = "cdn.example.com"
+    description = "primary"
+  }
+
+  domains {
+    name = "static.example.com"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceResource, "domain_certificates.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(testAccServiceResource, "domain_certificates.*", map[string]string{
+						"domain": "cdn.example.com",
+						"status": "NONE",
+					}),
+					testAccCheckFakeDomains(srv, "cdn.example.com", "domains.cachefly.net", "static.example.com"),
+				),
+			},
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Domains"
+  unique_name = "domains"
+
+  domains {
+    name        = "cdn.example.com"
+    description = "renamed"
+  }
+
+  domains {
+    name            = "assets.example.com"
+    validation_mode = "MANUAL"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFakeDomains(srv, "assets.example.com", "cdn.example.com", "domains.cachefly.net"),
+					testAccCheckFakeDomain(srv, "cdn.example.com", func(domain fakeapi.Domain) error {
+						if domain.Description != "renamed" {
+							return fmt.Errorf("expected description %q, got %q", "renamed", domain.Description)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:            testAccServiceResource,
+				ImportState:             true,
+				ImportStateIdFunc:       func(*terraform.State) (string, error) { return "unique_name:domains", nil },
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: testAccServiceImportIgnore,
+			},
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Domains"
+  unique_name = "domains"
+  auto_ssl    = true
+
+  domains {
+    name        = "cdn.example.com"
+    description = "renamed"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceResource, "auto_ssl", "true"),
+					testAccCheckFakeDomains(srv, "cdn.example.com", "domains.cachefly.net"),
+					resource.TestCheckTypeSetElemNestedAttrs(testAccServiceResource, "domain_certificates.*", map[string]string{
+						"domain": "cdn.example.com",
+						"status": "ISSUED",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCacheflyService_domainCertificates(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckServiceDeactivated(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Certificates"
+  unique_name = "certificates"
+
+  domains {
+    name         = "cdn.example.com"
+    certificates = ["cert-1", "cert-2"]
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceResource, "domains.0.certificates.#", "2"),
+					testAccCheckFakeDomain(srv, "cdn.example.com", func(domain fakeapi.Domain) error {
+						if strings.Join(domain.Certificates, ",") != "cert-1,cert-2" {
+							return fmt.Errorf("expected certificates cert-1,cert-2, got %v", domain.Certificates)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Certificates"
+  unique_name = "certificates"
+
+  domains {
+    name         = "cdn.example.com"
+    certificates = []
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceResource, "domains.0.certificates.#", "0"),
+					testAccCheckFakeDomain(srv, "cdn.example.com", func(domain fakeapi.Domain) error {
+						if len(domain.Certificates) != 0 {
+							return fmt.Errorf("expected the certificates to be detached, got %v", domain.Certificates)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCacheflyService_certificateTimeoutKeepsService(t *testing.T) {
+	srv := newTestServer(t)
+	srv.HoldCertificates = true
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckServiceDeactivated(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Slow"
+  unique_name = "slow"
+  auto_ssl    = true
+
+  domains {
+    name = "cdn.example.com"
+  }
+
+  timeouts {
+    create = "2s"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testAccServiceResource, "id"),
+					testAccCheckFakeService(srv, func(service fakeapi.Service) error {
+						if service.Status != "ACTIVE" || !service.AutoSsl {
+							return fmt.Errorf("expected an active service with AutoSSL, got %s (auto_ssl %t)", service.Status, service.AutoSsl)
+						}
+						return nil
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(testAccServiceResource, "domain_certificates.*", map[string]string{
+						"domain": "cdn.example.com",
+						"status": "PENDING_ISSUANCE",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCacheflyService_skipCertificateWait(t *testing.T) {
+	srv := newTestServer(t)
+	srv.HoldCertificates = true
+
+	var start time.Time
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckServiceDeactivated(srv),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { start = time.Now() },
+				Config: testAccServiceConfig(srv, `
+  name                  = "Nowait"
+  unique_name           = "nowait"
+  auto_ssl              = true
+  wait_for_certificates = false
+
+  domains {
+    name = "cdn.example.com"
+  }
+
+  timeouts {
+    create = "10m"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceResource, "wait_for_certificates", "false"),
+					resource.TestCheckTypeSetElemNestedAttrs(testAccServiceResource, "domain_certificates.*", map[string]string{
+						"domain": "cdn.example.com",
+						"status": "PENDING_ISSUANCE",
+					}),
+					func(*terraform.State) error {
+						if elapsed := time.Since(start); elapsed > time.Minute {
+							return fmt.Errorf("expected create to return without waiting for certificates, took %s", elapsed)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccCacheflyService_domainFailureRollsBack(t *testing.T) {
+	srv := newTestServer(t)
+	config := testAccServiceConfig(srv, `
+  name        = "Rollback"
+  unique_name = "rollback"
+
+  domains {
+    name = "a.example.com"
+  }
+
+  domains {
+    name = "b.example.com"
+  }
+`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckServiceDeactivated(srv),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					srv.InjectFault(fakeapi.Fault{Method: "POST", Path: "/api/2.5/services/*/domains", Status: http.StatusBadRequest, After: 1, Times: 1})
+				},
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Failed to configure domains for the new service`),
+			},
+			{
+				PreConfig: func() {
+					for _, service := range srv.Services() {
+						if service.UniqueName == "rollback" && service.Status != "DEACTIVATED" {
+							t.Fatalf("expected the failed service to be rolled back, got status %s", service.Status)
+						}
+					}
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceResource, "status", "ACTIVE"),
+					testAccCheckFakeDomains(srv, "a.example.com", "b.example.com", "rollback.cachefly.net"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCacheflyService_importUnknownUniqueName(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Missing"
+  unique_name = "missing"
+`),
+				ResourceName:  testAccServiceResource,
+				ImportState:   true,
+				ImportStateId: "unique_name:missing",
+				ExpectError:   regexp.MustCompile(`service with unique_name missing not found`),
+			},
+		},
+	})
+}
+
+func TestFlattenServiceDomains(t *testing.T) {
+	domains := []DomainResource{
+		{Name: "example.cachefly.net"},
+		{Name: "new.example.com", ValidationMode: "DNS", Certificates: []string{"c3"}},
+		{Name: "b.example.com", ValidationMode: "HTTP", Certificates: []string{"c2"}},
+		{Name: "a.example.com", Description: "first", Certificates: []string{"c1"}},
+	}
+	previous := []interface{}{
+		map[string]interface{}{"name": "a.example.com", "certificates": []interface{}{"c1"}},
+		map[string]interface{}{"name": "b.example.com", "certificates": []interface{}{}},
+	}
+
+	got := flattenServiceDomains(domains, previous, false)
+	expected := []interface{}{
+		map[string]interface{}{"name": "a.example.com", "description": "first", "validation_mode": "NONE", "certificates": []interface{}{"c1"}},
+		map[string]interface{}{"name": "b.example.com", "description": "", "validation_mode": "HTTP", "certificates": []interface{}{}},
+		map[string]interface{}{"name": "new.example.com", "description": "", "validation_mode": "DNS", "certificates": []interface{}{}},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+
+	imported := flattenServiceDomains(domains, nil, true)
+	if len(imported) != 3 || imported[0].(map[string]interface{})["name"] != "new.example.com" {
+		t.Fatalf("expected the API order on import, got %v", imported)
+	}
+	if certificates := imported[1].(map[string]interface{})["certificates"]; !reflect.DeepEqual(certificates, []interface{}{"c2"}) {
+		t.Fatalf("expected certificates to be read on import, got %v", certificates)
+	}
+}
+
+func TestDomainCertificateStatus(t *testing.T) {
+	testCases := []struct {
+		domain   DomainResource
+		autoSsl  bool
+		expected string
+	}{
+		{DomainResource{Name: "a.example.com", ValidationStatus: "VALIDATED", Certificates: []string{"c1"}}, false, "ISSUED"},
+		{DomainResource{Name: "a.example.com", ValidationStatus: "VALIDATED"}, true, "PENDING_ISSUANCE"},
+		{DomainResource{Name: "a.example.com", ValidationStatus: "PENDING"}, true, "PENDING_VALIDATION"},
+		{DomainResource{Name: "a.example.com", ValidationStatus: "VALIDATED"}, false, "NONE"},
+		{DomainResource{Name: "a.example.com", ValidationStatus: "PENDING"}, false, "NONE"},
+	}
+
+	for _, tc := range testCases {
+		if actual := domainCertificateStatus(tc.domain, tc.autoSsl); actual != tc.expected {
+			t.Errorf("%s (%s, auto_ssl %t): expected %s, got %s", tc.domain.Name, tc.domain.ValidationStatus, tc.autoSsl, tc.expected, actual)
+		}
+	}
+}
+
+func TestValidateDomainName(t *testing.T) {
+	tests := map[string]string{
+		"cdn.example.com":            "",
+		"*.example.com":              "",
+		"cdn.example.xn--p1ai":       "",
+		"xn--80ak6aa92e.com":         "",
+		"CDN.example.com":            "must be lowercase",
+		"example":                    "is not a valid domain name",
+		"cdn.example.xn--":           "is not a valid domain name",
+		"-cdn.example.com":           "is not a valid domain name",
+		"cdn.example.com.":           "is not a valid domain name",
+		"example.cachefly.net":       "is a CacheFly default domain",
+		"cdn.*.example.com":          "is not a valid domain name",
+		"cdn.example.123":            "is not a valid domain name",
+		"cdn_origin.example.com":     "is not a valid domain name",
+		"cdn.example.xn--mgbaam7a8h": "",
+	}
+
+	for name, expected := range tests {
+		err := validateDomainName(name)
+		if expected == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("%s: expected an error containing %q, got %v", name, expected, err)
+		}
+	}
+}
+
+func TestHashCredentials(t *testing.T) {
+	hash := hashCredentials("example", "AKIA", "secret")
+	if hash != hashCredentials("example", "AKIA", "secret") {
+		t.Fatal("expected the hash to be stable")
+	}
+	if hash == hashCredentials("other", "AKIA", "secret") {
+		t.Fatal("expected the hash to depend on the unique name")
+	}
+	if hashCredentials("example", "a:b", "c") == hashCredentials("example", "a", "b:c") {
+		t.Fatal("expected credentials containing a colon not to collide")
+	}
+}
+
+func TestAccCacheflyService_reactivate(t *testing.T) {
+	srv := newTestServer(t)
+	existing := srv.AddService(fakeapi.Service{Name: "Old", UniqueName: "legacy", Status: "DEACTIVATED"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckServiceDeactivated(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceConfig(srv, `
+  name        = "Legacy"
+  unique_name = "legacy"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceResource, "id", existing.ID),
+					resource.TestCheckResourceAttr(testAccServiceResource, "status", "ACTIVE"),
+					resource.TestCheckResourceAttr(testAccServiceResource, "name", "Legacy"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCacheflyService_reactivateDisabled(t *testing.T) {
+	srv := newTestServer(t)
+	srv.AddService(fakeapi.Service{Name: "Old", UniqueName: "legacy", Status: "DEACTIVATED"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceConfig(srv, `
+  name                = "Legacy"
+  unique_name         = "legacy"
+  reactivate_existing = false
+`),
+				ExpectError: regexp.MustCompile(`already exists and is DEACTIVATED`),
+			},
+		},
+	})
+}
+
+func TestAccCacheflyService_deletionPolicyDelete(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			for _, rs := range s.RootModule().Resources {
+				if _, ok := srv.Service(rs.Primary.ID); ok {
+					return fmt.Errorf("service %s still exists after destroy", rs.Primary.ID)
+				}
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceConfig(srv, `
+  name            = "Temporary"
+  unique_name     = "temporary"
+  deletion_policy = "delete"
+`),
+				Check: resource.TestCheckResourceAttr(testAccServiceResource, "status", "ACTIVE"),
+			},
+		},
+	})
+}
+
+func testAccServiceConfig(srv *fakeapi.Server, body string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "cachefly_service" "test" {
+%s
+}
+`, strings.TrimPrefix(body, "\n"))
+}
+
+// testAccCheckServiceDeactivated checks that destroyed services were deactivated rather than left active.
+func testAccCheckServiceDeactivated(srv *fakeapi.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "cachefly_service" {
+				continue
+			}
+			service, ok := srv.Service(rs.Primary.ID)
+			if !ok {
+				return fmt.Errorf("service %s no longer exists, expected it to be deactivated", rs.Primary.ID)
+			}
+			if service.Status != "DEACTIVATED" {
+				return fmt.Errorf("service %s is %s after destroy", rs.Primary.ID, service.Status)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccServiceID(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources[testAccServiceResource]
+	if !ok {
+		return "", fmt.Errorf("%s not found in state", testAccServiceResource)
+	}
+	return rs.Primary.ID, nil
+}
+
+func testAccCheckFakeService(srv *fakeapi.Server, check func(fakeapi.Service) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccServiceID(s)
+		if err != nil {
+			return err
+		}
+		service, ok := srv.Service(id)
+		if !ok {
+			return fmt.Errorf("service %s not found in the API", id)
+		}
+		return check(service)
+	}
+}
+
+// testAccCheckFakeOption compares an option section stored by the API with the expected JSON.
+func testAccCheckFakeOption(srv *fakeapi.Server, key, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccServiceID(s)
+		if err != nil {
+			return err
+		}
+		got, ok := srv.Options(id)[key]
+		if !ok {
+			return fmt.Errorf("option %s was never sent", key)
+		}
+
+		var gotValue, expectedValue interface{}
+		if err := json.Unmarshal(got, &gotValue); err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(expected), &expectedValue); err != nil {
+			return err
+		}
+		gotJSON, _ := json.Marshal(gotValue)
+		expectedJSON, _ := json.Marshal(expectedValue)
+		if string(gotJSON) != string(expectedJSON) {
+			return fmt.Errorf("option %s: expected %s, got %s", key, expectedJSON, gotJSON)
+		}
+		return nil
+	}
+}
+
+func testAccCheckFakeReverseProxy(srv *fakeapi.Server, check func(ReverseProxy) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccServiceID(s)
+		if err != nil {
+			return err
+		}
+		var rp ReverseProxy
+		if err := json.Unmarshal(srv.Options(id)["reverseProxy"], &rp); err != nil {
+			return fmt.Errorf("failed to decode reverseProxy option: %w", err)
+		}
+		return check(rp)
+	}
+}
+
+// testAccCheckFakeDomains checks the exact set of domain names stored by the API.
+func testAccCheckFakeDomains(srv *fakeapi.Server, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccServiceID(s)
+		if err != nil {
+			return err
+		}
+		var names []string
+		for _, domain := range srv.Domains(id) {
+			names = append(names, domain.Name)
+		}
+		sort.Strings(names)
+		if strings.Join(names, ",") != strings.Join(expected, ",") {
+			return fmt.Errorf("expected domains %v, got %v", expected, names)
+		}
+		return nil
+	}
+}
+
+func testAccCheckFakeDomain(srv *fakeapi.Server, name string, check func(fakeapi.Domain) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccServiceID(s)
+		if err != nil {
+			return err
+		}
+		for _, domain := range srv.Domains(id) {
+			if domain.Name == name {
+				return check(domain)
+			}
+		}
+		return fmt.Errorf("domain %s not found in the API", name)
+	}
+}
@@ -0,0 +1,214 @@
+package cachefly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// TokenAuth represents the URL signing options of a service.
+type TokenAuth struct {
+	Enabled         bool     `json:"enabled"`
+	Secret          string   `json:"secret,omitempty"`
+	SecondarySecret string   `json:"secondarySecret,omitempty"`
+	Paths           []string `json:"paths,omitempty"`
+	Algorithm       string   `json:"algorithm,omitempty"`
+	ExpiryParameter string   `json:"expiryParameter,omitempty"`
+	TokenParameter  string   `json:"tokenParameter,omitempty"`
+}
+
+var signingAlgorithms = []string{"MD5", "SHA1", "SHA256"}
+
+func resourceCacheflyServiceTokenAuth() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCacheflyServiceTokenAuthCreate,
+		ReadContext:   resourceCacheflyServiceTokenAuthRead,
+		UpdateContext: resourceCacheflyServiceTokenAuthUpdate,
+		DeleteContext: resourceCacheflyServiceTokenAuthDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the service to protect.",
+			},
+			"protected_paths": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^/`), "must start with '/'"),
+				},
+				Description: "Path patterns that require a signed URL, e.g. /premium/*.",
+			},
+			"secret": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringLenBetween(16, 256),
+				Description:  "The secret used to sign URLs. Between 16 and 256 characters.",
+			},
+			"keep_previous_secret": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "When the secret changes, keep accepting URLs signed with the previous secret until the next rotation, so URLs issued before the rotation stay valid.",
+			},
+			"previous_secret": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The previous secret, still accepted after a rotation when keep_previous_secret is enabled.",
+			},
+			"algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "SHA256",
+				ValidateFunc: validation.StringInSlice(signingAlgorithms, false),
+				Description:  "The HMAC hash algorithm used for the token. Possible values: MD5, SHA1, SHA256.",
+			},
+			"expiry_parameter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "expires",
+				Description: "The name of the query parameter carrying the expiry timestamp.",
+			},
+			"token_parameter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "token",
+				Description: "The name of the query parameter carrying the token.",
+			},
+		},
+	}
+}
+
+func resourceCacheflyServiceTokenAuthCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+	serviceID := d.Get("service_id").(string)
+
+	if err := putTokenAuth(client, serviceID, expandTokenAuth(d, "")); err != nil {
+		return diag.Errorf("failed to configure token authentication: %v", err)
+	}
+
+	d.SetId(serviceID)
+	d.Set("previous_secret", "")
+
+	return resourceCacheflyServiceTokenAuthRead(ctx, d, meta)
+}
+
+func resourceCacheflyServiceTokenAuthRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	tokenAuth, err := fetchTokenAuth(client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if tokenAuth == nil || !tokenAuth.Enabled {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("service_id", d.Id())
+	d.Set("protected_paths", tokenAuth.Paths)
+	d.Set("algorithm", tokenAuth.Algorithm)
+	d.Set("expiry_parameter", tokenAuth.ExpiryParameter)
+	d.Set("token_parameter", tokenAuth.TokenParameter)
+
+	// Secrets are only compared when the API returns them.
+	if tokenAuth.Secret != "" {
+		d.Set("secret", tokenAuth.Secret)
+	}
+	if tokenAuth.SecondarySecret != "" {
+		d.Set("previous_secret", tokenAuth.SecondarySecret)
+	}
+
+	return nil
+}
+
+func resourceCacheflyServiceTokenAuthUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	previousSecret := d.Get("previous_secret").(string)
+	if d.HasChange("secret") {
+		oldSecret, _ := d.GetChange("secret")
+		previousSecret = oldSecret.(string)
+	}
+	if !d.Get("keep_previous_secret").(bool) {
+		previousSecret = ""
+	}
+
+	if err := putTokenAuth(client, d.Id(), expandTokenAuth(d, previousSecret)); err != nil {
+		return diag.Errorf("failed to update token authentication: %v", err)
+	}
+
+	d.Set("previous_secret", previousSecret)
+
+	return resourceCacheflyServiceTokenAuthRead(ctx, d, meta)
+}
+
+func resourceCacheflyServiceTokenAuthDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	if err := putTokenAuth(client, d.Id(), TokenAuth{Enabled: false}); err != nil {
+		return diag.Errorf("failed to disable token authentication: %v", err)
+	}
+
+	return nil
+}
+
+func expandTokenAuth(d *schema.ResourceData, previousSecret string) TokenAuth {
+	return TokenAuth{
+		Enabled:         true,
+		Secret:          d.Get("secret").(string),
+		SecondarySecret: previousSecret,
+		Paths:           expandStringList(d.Get("protected_paths")),
+		Algorithm:       d.Get("algorithm").(string),
+		ExpiryParameter: d.Get("expiry_parameter").(string),
+		TokenParameter:  d.Get("token_parameter").(string),
+	}
+}
+
+// fetchTokenAuth returns the token authentication options of a service, or nil if the service does not exist.
+func fetchTokenAuth(client *CacheFlyClient, serviceID string) (*TokenAuth, error) {
+	url := fmt.Sprintf("%s/api/2.6/services/%s/options", client.APIURL, serviceID)
+	resp, err := makeRequestWithRetry(client, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch service options after retries: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	} else if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to fetch service options: HTTP %d. Response: %s", resp.StatusCode, string(body))
+	}
+
+	var options struct {
+		TokenAuth *TokenAuth `json:"tokenAuth"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&options); err != nil {
+		return nil, fmt.Errorf("failed to decode service options: %w", err)
+	}
+
+	return options.TokenAuth, nil
+}
+
+func putTokenAuth(client *CacheFlyClient, serviceID string, tokenAuth TokenAuth) error {
+	return updateServiceOptions(client, serviceID, map[string]interface{}{"tokenAuth": tokenAuth})
+}
@@ -0,0 +1,87 @@
+package cachefly
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccServiceTokenAuthResource = "cachefly_service_token_auth.test"
+
+func TestAccCacheflyServiceTokenAuth_basic(t *testing.T) {
+	srv := newTestServer(t)
+	service := srv.AddService(fakeapi.Service{Name: "Signed", UniqueName: "signed"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckFakeTokenAuth(srv, service.ID, func(tokenAuth TokenAuth) error { return nil }),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceTokenAuthConfig(srv, service.ID, `
+  protected_paths = ["/premium/*"]
+  secret          = "first-secret-0123456789"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceTokenAuthResource, "algorithm", "SHA256"),
+					resource.TestCheckResourceAttr(testAccServiceTokenAuthResource, "previous_secret", ""),
+					testAccCheckFakeTokenAuth(srv, service.ID, func(tokenAuth TokenAuth) error {
+						if !tokenAuth.Enabled || tokenAuth.Secret != "first-secret-0123456789" || tokenAuth.TokenParameter != "token" {
+							return fmt.Errorf("unexpected token authentication in the API: %+v", tokenAuth)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccServiceTokenAuthConfig(srv, service.ID, `
+  protected_paths = ["/premium/*", "/downloads/*"]
+  secret          = "second-secret-0123456789"
+  algorithm       = "SHA1"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccServiceTokenAuthResource, "protected_paths.#", "2"),
+					resource.TestCheckResourceAttr(testAccServiceTokenAuthResource, "previous_secret", "first-secret-0123456789"),
+					testAccCheckFakeTokenAuth(srv, service.ID, func(tokenAuth TokenAuth) error {
+						if tokenAuth.Secret != "second-secret-0123456789" || tokenAuth.SecondarySecret != "first-secret-0123456789" || tokenAuth.Algorithm != "SHA1" {
+							return fmt.Errorf("expected the rotated secrets in the API, got %+v", tokenAuth)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:            testAccServiceTokenAuthResource,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"keep_previous_secret"},
+			},
+		},
+	})
+}
+
+func testAccServiceTokenAuthConfig(srv *fakeapi.Server, serviceID, body string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "cachefly_service_token_auth" "test" {
+  service_id = %q
+%s}
+`, serviceID, body)
+}
+
+// testAccCheckFakeTokenAuth decodes the tokenAuth option stored by the API. With no resource
+// in the state, as after destroy, it checks that token authentication was disabled instead.
+func testAccCheckFakeTokenAuth(srv *fakeapi.Server, serviceID string, check func(TokenAuth) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var tokenAuth TokenAuth
+		if err := json.Unmarshal(srv.Options(serviceID)["tokenAuth"], &tokenAuth); err != nil {
+			return fmt.Errorf("failed to decode tokenAuth option: %w", err)
+		}
+		if _, ok := s.RootModule().Resources[testAccServiceTokenAuthResource]; !ok && tokenAuth.Enabled {
+			return fmt.Errorf("expected token authentication to be disabled on destroy")
+		}
+		return check(tokenAuth)
+	}
+}
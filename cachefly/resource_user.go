@@ -0,0 +1,210 @@
+package cachefly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// User represents a portal user of the account.
+type User struct {
+	ID          string   `json:"_id,omitempty"`
+	Email       string   `json:"email,omitempty"`
+	FullName    string   `json:"fullName,omitempty"`
+	Phone       string   `json:"phone,omitempty"`
+	Permissions []string `json:"permissions"`
+	Services    []string `json:"services"`
+	Status      string   `json:"status,omitempty"`
+	CreatedAt   string   `json:"createdAt,omitempty"`
+}
+
+var (
+	emailRegexp      = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	permissionRegexp = regexp.MustCompile(`^[A-Z][A-Z_]*$`)
+)
+
+func resourceCacheflyUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCacheflyUserCreate,
+		ReadContext:   resourceCacheflyUserRead,
+		UpdateContext: resourceCacheflyUserUpdate,
+		DeleteContext: resourceCacheflyUserDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(emailRegexp, "must be an email address"),
+				Description:  "The email address of the user, also used to sign in. An invitation is sent to it on creation.",
+			},
+			"full_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The full name of the user.",
+			},
+			"phone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The phone number of the user.",
+			},
+			"permissions": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(permissionRegexp, "must be an uppercase permission name, e.g. SERVICES_READ"),
+				},
+				Description: "The permissions granted to the user, e.g. ADMIN, SERVICES_MANAGE, SERVICES_READ, REPORTS_READ.",
+			},
+			"service_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Restrict the user to these services. The user can access every service when empty.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the user, e.g. INVITED or ACTIVE.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the user was created.",
+			},
+		},
+	}
+}
+
+func resourceCacheflyUserCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	body := expandUser(d)
+	body.Email = d.Get("email").(string)
+
+	resp, err := makeRequestWithRetry(client, "POST", fmt.Sprintf("%s/api/2.5/users", client.APIURL), body)
+	if err != nil {
+		return diag.Errorf("failed to create user after retries: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if err := handleResponse(resp); err != nil {
+		return diag.Errorf("failed to create user: %v", err)
+	}
+
+	var created User
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return diag.Errorf("failed to decode user response: %v", err)
+	}
+
+	d.SetId(created.ID)
+
+	return resourceCacheflyUserRead(ctx, d, meta)
+}
+
+func resourceCacheflyUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	user, err := fetchUser(client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if user == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("email", user.Email)
+	d.Set("full_name", user.FullName)
+	d.Set("phone", user.Phone)
+	d.Set("permissions", user.Permissions)
+	d.Set("service_ids", user.Services)
+	d.Set("status", user.Status)
+	d.Set("created_at", user.CreatedAt)
+
+	return nil
+}
+
+func resourceCacheflyUserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	url := fmt.Sprintf("%s/api/2.5/users/%s", client.APIURL, d.Id())
+	resp, err := makeRequestWithRetry(client, "PUT", url, expandUser(d))
+	if err != nil {
+		return diag.Errorf("failed to update user after retries: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if err := handleResponse(resp); err != nil {
+		return diag.Errorf("failed to update user: %v", err)
+	}
+
+	return resourceCacheflyUserRead(ctx, d, meta)
+}
+
+func resourceCacheflyUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*CacheFlyClient)
+
+	url := fmt.Sprintf("%s/api/2.5/users/%s", client.APIURL, d.Id())
+	resp, err := makeRequestWithRetry(client, "DELETE", url, nil)
+	if err != nil {
+		return diag.Errorf("failed to delete user after retries: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err := handleResponse(resp); err != nil {
+		return diag.Errorf("failed to delete user: %v", err)
+	}
+
+	return nil
+}
+
+func expandUser(d *schema.ResourceData) User {
+	return User{
+		FullName:    d.Get("full_name").(string),
+		Phone:       d.Get("phone").(string),
+		Permissions: expandSortedSet(d.Get("permissions")),
+		Services:    expandSortedSet(d.Get("service_ids")),
+	}
+}
+
+// fetchUser returns the user with the given ID, or nil if it does not exist.
+func fetchUser(client *CacheFlyClient, userID string) (*User, error) {
+	url := fmt.Sprintf("%s/api/2.5/users/%s", client.APIURL, userID)
+	resp, err := makeRequestWithRetry(client, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user after retries: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	} else if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to fetch user: HTTP %d. Response: %s", resp.StatusCode, string(body))
+	}
+
+	var user User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("failed to decode user response: %w", err)
+	}
+
+	return &user, nil
+}
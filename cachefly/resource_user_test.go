@@ -0,0 +1,107 @@
+package cachefly
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testAccUserResource = "cachefly_user.test"
+
+func TestAccCacheflyUser_basic(t *testing.T) {
+	srv := newTestServer(t)
+	service := srv.AddService(fakeapi.Service{Name: "Example", UniqueName: "example"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckUsersDeleted(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserConfig(srv, `
+  email       = "ops"
+  full_name   = "Ops"
+  permissions = ["SERVICES_READ"]
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must be an email address`),
+			},
+			{
+				Config: testAccUserConfig(srv, `
+  email       = "ops@example.com"
+  full_name   = "Ops"
+  permissions = ["SERVICES_READ"]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccUserResource, "status", "INVITED"),
+					resource.TestCheckResourceAttr(testAccUserResource, "service_ids.#", "0"),
+					resource.TestCheckResourceAttrSet(testAccUserResource, "created_at"),
+					testAccCheckFakeUser(srv, func(user fakeapi.User) error {
+						if user.Email != "ops@example.com" || fmt.Sprint(user.Permissions) != "[SERVICES_READ]" {
+							return fmt.Errorf("unexpected user in the API: %+v", user)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccUserConfig(srv, fmt.Sprintf(`
+  email       = "ops@example.com"
+  full_name   = "Operations"
+  phone       = "+1 555 0100"
+  permissions = ["SERVICES_READ", "SERVICES_MANAGE"]
+  service_ids = [%q]
+`, service.ID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testAccUserResource, "full_name", "Operations"),
+					resource.TestCheckResourceAttr(testAccUserResource, "permissions.#", "2"),
+					testAccCheckFakeUser(srv, func(user fakeapi.User) error {
+						if user.FullName != "Operations" || user.Phone != "+1 555 0100" || fmt.Sprint(user.Services) != fmt.Sprint([]string{service.ID}) {
+							return fmt.Errorf("unexpected user in the API: %+v", user)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:      testAccUserResource,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccUserConfig(srv *fakeapi.Server, body string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "cachefly_user" "test" {
+%s}
+`, body)
+}
+
+func testAccCheckFakeUser(srv *fakeapi.Server, check func(fakeapi.User) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[testAccUserResource]
+		if !ok {
+			return fmt.Errorf("%s not found in state", testAccUserResource)
+		}
+		for _, user := range srv.Users() {
+			if user.ID == rs.Primary.ID {
+				return check(user)
+			}
+		}
+		return fmt.Errorf("user %s not found in the API", rs.Primary.ID)
+	}
+}
+
+func testAccCheckUsersDeleted(srv *fakeapi.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if users := srv.Users(); len(users) != 0 {
+			return fmt.Errorf("expected every user to be deleted, got %d", len(users))
+		}
+		return nil
+	}
+}
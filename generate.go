@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/AlehYarmalovich/terraform-provider-cachefly/cachefly"
+)
+
+const generateUsage = `Usage: terraform-provider-cachefly generate [options]
+
+Writes Terraform configuration and import blocks for the services of a CacheFly account.
+The API token is read from the CACHEFLY_TOKEN environment variable.
+
+Options:
+`
+
+// runGenerate implements the generate subcommand.
+func runGenerate(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), generateUsage)
+		flags.PrintDefaults()
+	}
+
+	apiURL := flags.String("api-url", envOrDefault("CACHEFLY_API_URL", "https://api.cachefly.com"), "the base URL of the CacheFly API")
+	out := flags.String("out", ".", "the directory the .tf files are written to")
+	status := flags.String("status", "", "only generate services with this status, e.g. ACTIVE")
+	namePrefix := flags.String("name-prefix", "", "only generate services whose unique_name starts with this prefix")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	token := os.Getenv("CACHEFLY_TOKEN")
+	if token == "" {
+		return fmt.Errorf("CACHEFLY_TOKEN must be set")
+	}
+
+	client := cachefly.NewCacheFlyClient(*apiURL, token)
+	files, err := cachefly.GenerateConfig(context.Background(), client, cachefly.GenerateOptions{
+		Status:     *status,
+		NamePrefix: *namePrefix,
+	}, *out)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		fmt.Println(file)
+	}
+	return nil
+}
+
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,25 @@
+package fakeapi
+
+import (
+	"net/http"
+)
+
+// Account is the account returned by /accounts/me.
+type Account struct {
+	ID          string `json:"_id"`
+	CompanyName string `json:"companyName"`
+	Website     string `json:"website"`
+}
+
+// SetAccount replaces the account returned by /accounts/me.
+func (s *Server) SetAccount(account Account) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.account = account
+}
+
+func (s *Server) getAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.account)
+}
@@ -0,0 +1,143 @@
+package fakeapi
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Certificate is a TLS certificate as returned by the API. The private key is accepted on
+// upload and never returned.
+type Certificate struct {
+	ID                string   `json:"_id"`
+	Certificate       string   `json:"certificate,omitempty"`
+	CertificateKey    string   `json:"certificateKey,omitempty"`
+	SubjectCommonName string   `json:"subjectCommonName"`
+	SubjectNames      []string `json:"subjectNames"`
+	Issuer            string   `json:"issuer"`
+	NotBefore         string   `json:"notBefore"`
+	NotAfter          string   `json:"notAfter"`
+	Expired           bool     `json:"expired"`
+	AutoSsl           bool     `json:"autoSsl"`
+	CreatedAt         string   `json:"createdAt"`
+}
+
+// AddCertificate stores a certificate as it would be listed by the API, such as one
+// issued by AutoSSL. The ID and creation time are filled in when empty.
+func (s *Server) AddCertificate(certificate Certificate) Certificate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if certificate.ID == "" {
+		certificate.ID = s.newID()
+	}
+	if certificate.CreatedAt == "" {
+		certificate.CreatedAt = now()
+	}
+	s.certificates = append(s.certificates, &certificate)
+	return certificate
+}
+
+// Certificates returns the stored certificates.
+func (s *Server) Certificates() []Certificate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	certificates := make([]Certificate, 0, len(s.certificates))
+	for _, certificate := range s.certificates {
+		certificates = append(certificates, *certificate)
+	}
+	return certificates
+}
+
+func (s *Server) listCertificates(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	search := r.URL.Query().Get("search")
+	certificates := make([]Certificate, 0, len(s.certificates))
+	for _, certificate := range s.certificates {
+		if search != "" && !certificateMatches(certificate, search) {
+			continue
+		}
+		listed := *certificate
+		listed.CertificateKey = ""
+		certificates = append(certificates, listed)
+	}
+
+	writeJSON(w, http.StatusOK, paginate(r, certificates))
+}
+
+func certificateMatches(certificate *Certificate, search string) bool {
+	for _, name := range append([]string{certificate.SubjectCommonName}, certificate.SubjectNames...) {
+		if strings.Contains(name, search) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) createCertificate(w http.ResponseWriter, r *http.Request) {
+	var certificate Certificate
+	if !decodeBody(w, r, &certificate) {
+		return
+	}
+	if certificate.Certificate == "" || certificate.CertificateKey == "" {
+		writeError(w, http.StatusBadRequest, "certificate and certificateKey are required")
+		return
+	}
+
+	block, _ := pem.Decode([]byte(certificate.Certificate))
+	if block == nil || block.Type != "CERTIFICATE" {
+		writeError(w, http.StatusBadRequest, "certificate is not a PEM encoded certificate")
+		return
+	}
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "certificate could not be parsed: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	certificate.ID = s.newID()
+	certificate.CertificateKey = ""
+	certificate.SubjectCommonName = parsed.Subject.CommonName
+	certificate.SubjectNames = append([]string{}, parsed.DNSNames...)
+	certificate.Issuer = parsed.Issuer.CommonName
+	certificate.NotBefore = parsed.NotBefore.UTC().Format(time.RFC3339)
+	certificate.NotAfter = parsed.NotAfter.UTC().Format(time.RFC3339)
+	certificate.Expired = time.Now().After(parsed.NotAfter)
+	certificate.CreatedAt = now()
+	s.certificates = append(s.certificates, &certificate)
+
+	writeJSON(w, http.StatusCreated, certificate)
+}
+
+func (s *Server) getCertificate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, certificate := range s.certificates {
+		if certificate.ID == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, *certificate)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "certificate not found")
+}
+
+func (s *Server) deleteCertificate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, certificate := range s.certificates {
+		if certificate.ID == r.PathValue("id") {
+			s.certificates = append(s.certificates[:i], s.certificates[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "certificate not found")
+}
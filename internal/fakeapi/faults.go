@@ -0,0 +1,141 @@
+package fakeapi
+
+import (
+	"net/http"
+	"path"
+	"time"
+)
+
+// FaultKind selects how a Fault breaks a request.
+type FaultKind int
+
+const (
+	// FaultStatus answers with Status and Body instead of handling the request.
+	FaultStatus FaultKind = iota
+	// FaultDrop closes the connection without writing a response.
+	FaultDrop
+	// FaultRateLimit answers 429 Too Many Requests with the RetryAfter header.
+	FaultRateLimit
+	// FaultSlow waits for Delay and then handles the request normally.
+	FaultSlow
+	// FaultMalformedJSON answers 200 with a truncated JSON body.
+	FaultMalformedJSON
+)
+
+// Fault breaks matching requests.
+type Fault struct {
+	Kind FaultKind
+	// Method matches the request method. Empty matches every method.
+	Method string
+	// Path is a path.Match pattern for the request path, e.g. /api/2.5/services/*/domains.
+	Path string
+	// Status is the status code returned by FaultStatus, 500 when zero.
+	Status int
+	// Body is the response body of FaultStatus and FaultMalformedJSON. A JSON error message,
+	// respectively a truncated list, is returned when empty.
+	Body string
+	// RetryAfter is the Retry-After header value of FaultRateLimit, in seconds or as an HTTP date.
+	RetryAfter string
+	// Delay is how long FaultSlow holds the request.
+	Delay time.Duration
+	// After is the number of matching requests let through before the fault applies, to
+	// break the second of several similar requests.
+	After int
+	// Times is the number of requests the fault applies to. Zero means every request.
+	Times int
+}
+
+// InjectFault registers a fault. Faults are matched in the order they were injected.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// matchFault returns the first fault matching the request and consumes one use of it.
+// Callers must hold s.mu.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if ok, _ := path.Match(f.Path, r.URL.Path); !ok {
+			continue
+		}
+		if f.After > 0 {
+			f.After--
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		fault := *f
+		return &fault
+	}
+	return nil
+}
+
+// serveFault applies a fault and reports whether the request has been answered.
+func (s *Server) serveFault(w http.ResponseWriter, r *http.Request, fault *Fault) bool {
+	switch fault.Kind {
+	case FaultDrop:
+		hijacker, ok := w.(http.Hijacker)
+		if !ok {
+			panic("fakeapi: response writer does not support hijacking")
+		}
+		conn, _, err := hijacker.Hijack()
+		if err == nil {
+			conn.Close()
+		}
+		return true
+
+	case FaultRateLimit:
+		if fault.RetryAfter != "" {
+			w.Header().Set("Retry-After", fault.RetryAfter)
+		}
+		writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+		return true
+
+	case FaultSlow:
+		select {
+		case <-time.After(fault.Delay):
+			return false
+		case <-r.Context().Done():
+			return true
+		}
+
+	case FaultMalformedJSON:
+		body := fault.Body
+		if body == "" {
+			body = `{"meta":{"limit":10,"offset":0,"count":1},"data":[{"_id":`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+		return true
+
+	default:
+		status := fault.Status
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+		if fault.Body != "" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			w.Write([]byte(fault.Body))
+		} else {
+			writeError(w, status, "injected fault")
+		}
+		return true
+	}
+}
@@ -0,0 +1,80 @@
+package fakeapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// Meta is the pagination block returned by list endpoints.
+type Meta struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	Count  int `json:"count"`
+}
+
+type listResponse[T any] struct {
+	Meta Meta `json:"meta"`
+	Data []T  `json:"data"`
+}
+
+const defaultPageSize = 10
+
+// paginate returns the page of items selected by the limit and offset query parameters.
+func paginate[T any](r *http.Request, items []T) listResponse[T] {
+	limit := queryInt(r, "limit", defaultPageSize)
+	offset := queryInt(r, "offset", 0)
+
+	page := listResponse[T]{
+		Meta: Meta{Limit: limit, Offset: offset, Count: len(items)},
+		Data: []T{},
+	}
+	if offset < len(items) {
+		end := min(offset+limit, len(items))
+		page.Data = items[offset:end]
+	}
+	return page
+}
+
+func queryInt(r *http.Request, key string, fallback int) int {
+	v, err := strconv.Atoi(r.URL.Query().Get(key))
+	if err != nil || v < 0 {
+		return fallback
+	}
+	return v
+}
+
+// readBody reads the request body and puts it back so handlers can decode it again.
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// decodeBody decodes a JSON request body into out, answering 400 on failure.
+func decodeBody(w http.ResponseWriter, r *http.Request, out interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(out); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid JSON body: %v", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
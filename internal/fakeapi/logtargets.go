@@ -0,0 +1,130 @@
+package fakeapi
+
+import (
+	"net/http"
+)
+
+// LogTarget is a log delivery target. Credentials and headers are accepted on create and
+// update and never returned.
+type LogTarget struct {
+	ID                 string            `json:"_id"`
+	Name               string            `json:"name"`
+	Type               string            `json:"type"`
+	Endpoint           string            `json:"endpoint,omitempty"`
+	Bucket             string            `json:"bucket,omitempty"`
+	Prefix             string            `json:"prefix,omitempty"`
+	Region             string            `json:"region,omitempty"`
+	AccessKey          string            `json:"accessKey,omitempty"`
+	SecretKey          string            `json:"secretKey,omitempty"`
+	ServiceAccountJSON string            `json:"serviceAccountJson,omitempty"`
+	Headers            map[string]string `json:"headers,omitempty"`
+	Format             string            `json:"format"`
+	SamplingRate       float64           `json:"samplingRate"`
+	Services           []string          `json:"services"`
+}
+
+// LogTargets returns the stored log targets, credentials included.
+func (s *Server) LogTargets() []LogTarget {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	targets := make([]LogTarget, 0, len(s.logTargets))
+	for _, target := range s.logTargets {
+		targets = append(targets, *target)
+	}
+	return targets
+}
+
+// redacted returns the target as the API returns it.
+func (target LogTarget) redacted() LogTarget {
+	target.AccessKey = ""
+	target.SecretKey = ""
+	target.ServiceAccountJSON = ""
+	target.Headers = nil
+	return target
+}
+
+func validLogTarget(w http.ResponseWriter, target LogTarget) bool {
+	switch {
+	case target.Name == "":
+		writeError(w, http.StatusBadRequest, "name is required")
+	case target.Type != "S3" && target.Type != "GCS" && target.Type != "HTTP" && target.Type != "SYSLOG":
+		writeError(w, http.StatusBadRequest, "unknown log target type "+target.Type)
+	default:
+		return true
+	}
+	return false
+}
+
+func (s *Server) lookupLogTarget(w http.ResponseWriter, r *http.Request) (int, *LogTarget) {
+	for i, target := range s.logTargets {
+		if target.ID == r.PathValue("id") {
+			return i, target
+		}
+	}
+	writeError(w, http.StatusNotFound, "log target not found")
+	return -1, nil
+}
+
+func (s *Server) createLogTarget(w http.ResponseWriter, r *http.Request) {
+	var target LogTarget
+	if !decodeBody(w, r, &target) || !validLogTarget(w, target) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	target.ID = s.newID()
+	if target.Services == nil {
+		target.Services = []string{}
+	}
+	s.logTargets = append(s.logTargets, &target)
+
+	writeJSON(w, http.StatusCreated, target.redacted())
+}
+
+func (s *Server) getLogTarget(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, target := s.lookupLogTarget(w, r); target != nil {
+		writeJSON(w, http.StatusOK, target.redacted())
+	}
+}
+
+func (s *Server) updateLogTarget(w http.ResponseWriter, r *http.Request) {
+	var update LogTarget
+	if !decodeBody(w, r, &update) || !validLogTarget(w, update) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, target := s.lookupLogTarget(w, r)
+	if target == nil {
+		return
+	}
+	if update.Type != target.Type {
+		writeError(w, http.StatusBadRequest, "type cannot be changed")
+		return
+	}
+
+	update.ID = target.ID
+	if update.Services == nil {
+		update.Services = []string{}
+	}
+	*target = update
+
+	writeJSON(w, http.StatusOK, target.redacted())
+}
+
+func (s *Server) deleteLogTarget(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i, target := s.lookupLogTarget(w, r); target != nil {
+		s.logTargets = append(s.logTargets[:i], s.logTargets[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
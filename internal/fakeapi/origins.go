@@ -0,0 +1,39 @@
+package fakeapi
+
+import (
+	"net/http"
+)
+
+// Origin is an origin as returned by the API.
+type Origin struct {
+	ID       string `json:"_id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Hostname string `json:"hostname,omitempty"`
+}
+
+// AddOrigin stores an origin. The ID is filled in when empty.
+func (s *Server) AddOrigin(origin Origin) Origin {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if origin.ID == "" {
+		origin.ID = s.newID()
+	}
+	s.origins = append(s.origins, &origin)
+	return origin
+}
+
+func (s *Server) listOrigins(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	originType := r.URL.Query().Get("type")
+	origins := make([]Origin, 0, len(s.origins))
+	for _, origin := range s.origins {
+		if originType == "" || origin.Type == originType {
+			origins = append(origins, *origin)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, paginate(r, origins))
+}
@@ -0,0 +1,81 @@
+package fakeapi
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Purge is a cache purge request as returned by the API.
+type Purge struct {
+	ID          string   `json:"_id"`
+	ServiceID   string   `json:"service"`
+	Paths       []string `json:"paths"`
+	Status      string   `json:"status"`
+	CreatedAt   string   `json:"createdAt"`
+	CompletedAt string   `json:"completedAt,omitempty"`
+}
+
+// Purges returns the purge requests received so far.
+func (s *Server) Purges() []Purge {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	purges := make([]Purge, 0, len(s.purges))
+	for _, purge := range s.purges {
+		purges = append(purges, *purge)
+	}
+	return purges
+}
+
+func (s *Server) createPurge(w http.ResponseWriter, r *http.Request) {
+	var purge Purge
+	if !decodeBody(w, r, &purge) {
+		return
+	}
+	if len(purge.Paths) == 0 {
+		writeError(w, http.StatusBadRequest, "paths are required")
+		return
+	}
+	for _, path := range purge.Paths {
+		if path != "*" && !strings.HasPrefix(path, "/") {
+			writeError(w, http.StatusBadRequest, "invalid purge path "+path)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lookupService(w, r) == nil {
+		return
+	}
+
+	purge.ID = s.newID()
+	purge.ServiceID = r.PathValue("id")
+	purge.Status = "PENDING"
+	purge.CreatedAt = now()
+	s.purges = append(s.purges, &purge)
+
+	writeJSON(w, http.StatusCreated, purge)
+}
+
+// getPurge reports a pending purge as finished with PurgeStatus.
+func (s *Server) getPurge(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, purge := range s.purges {
+		if purge.ID != r.PathValue("purgeID") || purge.ServiceID != r.PathValue("id") {
+			continue
+		}
+		if purge.Status == "PENDING" && s.PurgeStatus != "PENDING" {
+			purge.Status = s.PurgeStatus
+			if purge.Status == "" {
+				purge.Status = "COMPLETED"
+			}
+			purge.CompletedAt = now()
+		}
+		writeJSON(w, http.StatusOK, *purge)
+		return
+	}
+	writeError(w, http.StatusNotFound, "purge not found")
+}
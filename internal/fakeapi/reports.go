@@ -0,0 +1,98 @@
+package fakeapi
+
+import (
+	"net/http"
+	"time"
+)
+
+// StatsPoint is the traffic of one period, or the totals, of a report.
+type StatsPoint struct {
+	Timestamp   string           `json:"timestamp,omitempty"`
+	Requests    int64            `json:"requests"`
+	Bytes       int64            `json:"bytes"`
+	Hits        int64            `json:"hits"`
+	StatusCodes map[string]int64 `json:"statusCodes"`
+}
+
+// Report is a traffic report as returned by the reports endpoints.
+type Report struct {
+	Totals StatsPoint   `json:"totals"`
+	Data   []StatsPoint `json:"data"`
+}
+
+// Every service of the fake serves the same traffic in every period, so reports are
+// predictable: 1000 requests, 900 of them cache hits, 950 answered with 200 and 50 with 404.
+const (
+	periodRequests = 1000
+	periodHits     = 900
+	periodBytes    = 1 << 20
+)
+
+var granularities = map[string]func(time.Time) time.Time{
+	"HOUR":  func(t time.Time) time.Time { return t.Add(time.Hour) },
+	"DAY":   func(t time.Time) time.Time { return t.AddDate(0, 0, 1) },
+	"MONTH": func(t time.Time) time.Time { return t.AddDate(0, 1, 0) },
+}
+
+func (s *Server) accountReport(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	services := len(s.services)
+	s.mu.Unlock()
+
+	writeReport(w, r, int64(services))
+}
+
+func (s *Server) serviceReport(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	found := s.lookupService(w, r) != nil
+	s.mu.Unlock()
+
+	if found {
+		writeReport(w, r, 1)
+	}
+}
+
+func writeReport(w http.ResponseWriter, r *http.Request, services int64) {
+	from, err := time.Parse(time.RFC3339, r.URL.Query().Get("from"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "from must be an RFC 3339 time")
+		return
+	}
+	to, err := time.Parse(time.RFC3339, r.URL.Query().Get("to"))
+	if err != nil || !to.After(from) {
+		writeError(w, http.StatusBadRequest, "to must be an RFC 3339 time after from")
+		return
+	}
+	next, ok := granularities[r.URL.Query().Get("granularity")]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "granularity must be HOUR, DAY or MONTH")
+		return
+	}
+
+	report := Report{
+		Totals: StatsPoint{StatusCodes: map[string]int64{}},
+		Data:   []StatsPoint{},
+	}
+	for t := from; t.Before(to); t = next(t) {
+		point := StatsPoint{
+			Timestamp: t.UTC().Format(time.RFC3339),
+			Requests:  periodRequests * services,
+			Bytes:     periodBytes * services,
+			Hits:      periodHits * services,
+			StatusCodes: map[string]int64{
+				"200": (periodRequests - 50) * services,
+				"404": 50 * services,
+			},
+		}
+		report.Data = append(report.Data, point)
+
+		report.Totals.Requests += point.Requests
+		report.Totals.Bytes += point.Bytes
+		report.Totals.Hits += point.Hits
+		for code, count := range point.StatusCodes {
+			report.Totals.StatusCodes[code] += count
+		}
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,99 @@
+package fakeapi
+
+import (
+	"net/http"
+)
+
+// Rule is an edge rule as stored by the 2.6 rules endpoint.
+type Rule struct {
+	Name       string          `json:"name,omitempty"`
+	Enabled    bool            `json:"enabled"`
+	Priority   int             `json:"priority"`
+	Conditions []RuleCondition `json:"conditions"`
+	Actions    []RuleAction    `json:"actions"`
+}
+
+// RuleCondition is a request match of a Rule.
+type RuleCondition struct {
+	Type     string   `json:"type"`
+	Operator string   `json:"operator"`
+	Name     string   `json:"name,omitempty"`
+	Values   []string `json:"values"`
+}
+
+// RuleAction is an action of a Rule. TTL is a pointer because 0 disables caching.
+type RuleAction struct {
+	Type        string `json:"type"`
+	HeaderName  string `json:"headerName,omitempty"`
+	HeaderValue string `json:"headerValue,omitempty"`
+	Target      string `json:"target,omitempty"`
+	StatusCode  int    `json:"statusCode,omitempty"`
+	TTL         *int   `json:"ttl,omitempty"`
+}
+
+type rulesBody struct {
+	Rules []Rule `json:"rules"`
+}
+
+// defaultRuleStatusCodes are the status codes the API fills in when an action omits one.
+var defaultRuleStatusCodes = map[string]int{
+	"REDIRECT": http.StatusFound,
+	"DENY":     http.StatusForbidden,
+}
+
+// Rules returns the edge rules of a service.
+func (s *Server) Rules(serviceID string) []Rule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Rule(nil), s.rules[serviceID]...)
+}
+
+func (s *Server) getRules(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lookupService(w, r) == nil {
+		return
+	}
+
+	rules := s.rules[r.PathValue("id")]
+	if rules == nil {
+		rules = []Rule{}
+	}
+	writeJSON(w, http.StatusOK, rulesBody{Rules: rules})
+}
+
+func (s *Server) updateRules(w http.ResponseWriter, r *http.Request) {
+	var body rulesBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	for i, rule := range body.Rules {
+		if len(rule.Conditions) == 0 || len(rule.Actions) == 0 {
+			writeError(w, http.StatusBadRequest, "rules need at least one condition and one action")
+			return
+		}
+		for j, action := range rule.Actions {
+			if action.StatusCode == 0 {
+				body.Rules[i].Actions[j].StatusCode = defaultRuleStatusCodes[action.Type]
+			}
+			if action.Type == "TTL_OVERRIDE" && action.TTL == nil {
+				writeError(w, http.StatusBadRequest, "TTL_OVERRIDE actions require a ttl")
+				return
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lookupService(w, r) == nil {
+		return
+	}
+
+	if body.Rules == nil {
+		body.Rules = []Rule{}
+	}
+	s.rules[r.PathValue("id")] = body.Rules
+	writeJSON(w, http.StatusOK, body)
+}
@@ -0,0 +1,197 @@
+// Package fakeapi provides an in-memory stand-in for the CacheFly API, served over
+// httptest, so the provider can be tested without network access or a CacheFly account.
+//
+// Every endpoint the provider calls is implemented, and requests to any other path are
+// answered with 404 so a missing route fails a test instead of passing silently. State
+// lives in memory for the lifetime of the Server, list endpoints paginate with the same
+// meta block as the real API, and faults and latency can be injected per request.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultToken is the API token accepted by a Server unless another one is configured.
+const DefaultToken = "fake-cachefly-token"
+
+// Request is a request received by the Server, recorded for assertions.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+}
+
+// Server is an in-memory CacheFly API.
+type Server struct {
+	*httptest.Server
+
+	// Token is the API token expected in the Authorization header. Tokens created through
+	// the tokens endpoint are accepted as well until they are revoked.
+	Token string
+
+	mu       sync.Mutex
+	latency  time.Duration
+	faults   []*Fault
+	requests []Request
+	nextID   int
+
+	account  Account
+	services map[string]*Service
+	order    []string
+	domains  map[string][]*Domain
+	options  map[string]map[string]json.RawMessage
+	origins  []*Origin
+	tokens   []*Token
+
+	rules        map[string][]Rule
+	certificates []*Certificate
+	purges       []*Purge
+	users        []*User
+	logTargets   []*LogTarget
+
+	// DomainValidationStatus is the validation status given to new custom domains.
+	DomainValidationStatus string
+
+	// HoldCertificates stops AutoSSL from issuing certificates, as when issuance takes longer
+	// than the provider waits.
+	HoldCertificates bool
+
+	// PurgeStatus is the status a pending purge reports once it is polled, COMPLETED when
+	// empty. Set it to FAILED to fail purges, or to PENDING to keep them pending.
+	PurgeStatus string
+}
+
+// New starts a Server. Callers must Close it when done.
+func New() *Server {
+	s := &Server{
+		Token:                  DefaultToken,
+		account:                Account{ID: "000000000000000000000001", CompanyName: "Example Inc", Website: "https://example.com"},
+		services:               make(map[string]*Service),
+		domains:                make(map[string][]*Domain),
+		options:                make(map[string]map[string]json.RawMessage),
+		rules:                  make(map[string][]Rule),
+		DomainValidationStatus: "VALIDATED",
+	}
+	s.Server = httptest.NewServer(s.handler())
+	return s
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/2.5/accounts/me", s.getAccount)
+	mux.HandleFunc("GET /api/2.5/origins", s.listOrigins)
+
+	mux.HandleFunc("GET /api/2.5/services", s.listServices)
+	mux.HandleFunc("POST /api/2.5/services", s.createService)
+	mux.HandleFunc("GET /api/2.5/services/{id}", s.getService)
+	mux.HandleFunc("PUT /api/2.5/services/{id}", s.updateService)
+	mux.HandleFunc("DELETE /api/2.5/services/{id}", s.deleteService)
+	mux.HandleFunc("PUT /api/2.5/services/{id}/activate", s.setServiceStatus("ACTIVE"))
+	mux.HandleFunc("PUT /api/2.5/services/{id}/deactivate", s.setServiceStatus("DEACTIVATED"))
+
+	mux.HandleFunc("GET /api/2.5/services/{id}/domains", s.listDomains)
+	mux.HandleFunc("POST /api/2.5/services/{id}/domains", s.createDomain)
+	mux.HandleFunc("PUT /api/2.5/services/{id}/domains/{domainID}", s.updateDomain)
+	mux.HandleFunc("DELETE /api/2.5/services/{id}/domains/{domainID}", s.deleteDomain)
+
+	mux.HandleFunc("POST /api/2.5/services/{id}/purge", s.createPurge)
+	mux.HandleFunc("GET /api/2.5/services/{id}/purge/{purgeID}", s.getPurge)
+
+	mux.HandleFunc("GET /api/2.5/certificates", s.listCertificates)
+	mux.HandleFunc("POST /api/2.5/certificates", s.createCertificate)
+	mux.HandleFunc("GET /api/2.5/certificates/{id}", s.getCertificate)
+	mux.HandleFunc("DELETE /api/2.5/certificates/{id}", s.deleteCertificate)
+
+	mux.HandleFunc("GET /api/2.5/users", s.listUsers)
+	mux.HandleFunc("POST /api/2.5/users", s.createUser)
+	mux.HandleFunc("GET /api/2.5/users/{id}", s.getUser)
+	mux.HandleFunc("PUT /api/2.5/users/{id}", s.updateUser)
+	mux.HandleFunc("DELETE /api/2.5/users/{id}", s.deleteUser)
+
+	mux.HandleFunc("POST /api/2.5/logtargets", s.createLogTarget)
+	mux.HandleFunc("GET /api/2.5/logtargets/{id}", s.getLogTarget)
+	mux.HandleFunc("PUT /api/2.5/logtargets/{id}", s.updateLogTarget)
+	mux.HandleFunc("DELETE /api/2.5/logtargets/{id}", s.deleteLogTarget)
+
+	mux.HandleFunc("GET /api/2.5/reports/account", s.accountReport)
+	mux.HandleFunc("GET /api/2.5/reports/services/{id}", s.serviceReport)
+
+	mux.HandleFunc("POST /api/2.5/tokens", s.createToken)
+	mux.HandleFunc("GET /api/2.5/tokens/{id}", s.getToken)
+	mux.HandleFunc("DELETE /api/2.5/tokens/{id}", s.deleteToken)
+
+	mux.HandleFunc("GET /api/2.6/services/{id}/options", s.getOptions)
+	mux.HandleFunc("PUT /api/2.6/services/{id}/options", s.updateOptions)
+	mux.HandleFunc("GET /api/2.6/services/{id}/rules", s.getRules)
+	mux.HandleFunc("PUT /api/2.6/services/{id}/rules", s.updateRules)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: string(body)})
+		latency := s.latency
+		fault := s.matchFault(r)
+		authorized := s.validToken(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		s.mu.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if !authorized {
+			writeError(w, http.StatusUnauthorized, "invalid or missing API token")
+			return
+		}
+
+		if fault != nil && s.serveFault(w, r, fault) {
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// newID returns a new object ID in the format used by the API. Callers must hold s.mu.
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("%024x", 0x1000+s.nextID)
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
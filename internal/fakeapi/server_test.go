@@ -0,0 +1,535 @@
+package fakeapi
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func do(t *testing.T, s *Server, method, path string, body interface{}, out interface{}) int {
+	t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(payload)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequest(method, s.URL+path, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.Token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: failed to decode response: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestServerRequiresToken(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	resp, err := http.Get(s.URL + "/api/2.5/accounts/me")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a token, got %d", resp.StatusCode)
+	}
+}
+
+func TestServerServiceLifecycle(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	var created Service
+	if status := do(t, s, "POST", "/api/2.5/services", map[string]string{"name": "Example", "uniqueName": "example"}, &created); status != http.StatusCreated {
+		t.Fatalf("create: got status %d", status)
+	}
+	if created.ID == "" || created.Status != "ACTIVE" {
+		t.Fatalf("unexpected created service: %+v", created)
+	}
+
+	if status := do(t, s, "POST", "/api/2.5/services", map[string]string{"name": "Other", "uniqueName": "example"}, nil); status != http.StatusConflict {
+		t.Fatalf("duplicate uniqueName: expected 409, got %d", status)
+	}
+
+	do(t, s, "PUT", "/api/2.5/services/"+created.ID, map[string]interface{}{"description": "updated"}, nil)
+	do(t, s, "PUT", "/api/2.5/services/"+created.ID+"/deactivate", nil, nil)
+
+	service, ok := s.Service(created.ID)
+	if !ok || service.Description != "updated" || service.Status != "DEACTIVATED" || service.Name != "Example" {
+		t.Fatalf("unexpected stored service: %+v", service)
+	}
+
+	if status := do(t, s, "DELETE", "/api/2.5/services/"+created.ID, nil, nil); status != http.StatusOK {
+		t.Fatalf("delete: got status %d", status)
+	}
+	if status := do(t, s, "GET", "/api/2.5/services/"+created.ID, nil, nil); status != http.StatusNotFound {
+		t.Fatalf("get after delete: expected 404, got %d", status)
+	}
+}
+
+func TestServerPagination(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	for i := 0; i < 25; i++ {
+		s.AddService(Service{Name: fmt.Sprintf("Service %d", i), UniqueName: fmt.Sprintf("service%d", i)})
+	}
+
+	var page listResponse[Service]
+	do(t, s, "GET", "/api/2.5/services?limit=10&offset=20", nil, &page)
+
+	if page.Meta.Count != 25 || page.Meta.Limit != 10 || page.Meta.Offset != 20 {
+		t.Fatalf("unexpected meta: %+v", page.Meta)
+	}
+	if len(page.Data) != 5 || page.Data[0].UniqueName != "service20" {
+		t.Fatalf("unexpected page: %+v", page.Data)
+	}
+}
+
+func TestServerDomains(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	service := s.AddService(Service{Name: "Example", UniqueName: "example"})
+	path := "/api/2.5/services/" + service.ID + "/domains"
+
+	var domain Domain
+	if status := do(t, s, "POST", path, map[string]string{"name": "cdn.example.com", "validationMode": "MANUAL"}, &domain); status != http.StatusCreated {
+		t.Fatalf("create domain: got status %d", status)
+	}
+	if status := do(t, s, "POST", path, map[string]string{"name": "cdn.example.com"}, nil); status != http.StatusConflict {
+		t.Fatalf("duplicate domain: expected 409, got %d", status)
+	}
+
+	var page listResponse[Domain]
+	do(t, s, "GET", path, nil, &page)
+	if page.Meta.Count != 2 {
+		t.Fatalf("expected the default and the custom domain, got %+v", page.Data)
+	}
+
+	do(t, s, "PUT", "/api/2.5/services/"+service.ID, map[string]bool{"autoSsl": true}, nil)
+	for _, d := range s.Domains(service.ID) {
+		if d.Name == "cdn.example.com" && len(d.Certificates) != 1 {
+			t.Fatalf("expected a certificate once autoSsl is enabled, got %+v", d)
+		}
+	}
+
+	do(t, s, "DELETE", path+"/"+domain.ID, nil, nil)
+	if domains := s.Domains(service.ID); len(domains) != 1 {
+		t.Fatalf("expected only the default domain after delete, got %+v", domains)
+	}
+}
+
+func TestServerOptions(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	service := s.AddService(Service{Name: "Example", UniqueName: "example"})
+	path := "/api/2.6/services/" + service.ID + "/options"
+
+	do(t, s, "PUT", path, map[string]interface{}{
+		"error_ttl":    map[string]interface{}{"enabled": true, "value": 60},
+		"sharedshield": map[string]interface{}{"enabled": false},
+	}, nil)
+	do(t, s, "PUT", path, map[string]interface{}{"edgetoorigin": true}, nil)
+
+	var options map[string]json.RawMessage
+	do(t, s, "GET", path, nil, &options)
+
+	if string(options["edgetoorigin"]) != "true" {
+		t.Errorf("edgetoorigin: got %s", options["edgetoorigin"])
+	}
+	if _, ok := options["error_ttl"]; !ok {
+		t.Errorf("error_ttl was lost by a later partial update")
+	}
+	if _, ok := options["sharedshield"]; ok {
+		t.Errorf("disabled sharedshield should be omitted, got %s", options["sharedshield"])
+	}
+	if _, ok := options["reverseProxy"]; !ok {
+		t.Errorf("reverseProxy should always be returned")
+	}
+}
+
+func TestServerTokens(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	var token Token
+	if status := do(t, s, "POST", "/api/2.5/tokens", map[string]interface{}{"name": "ci", "scopes": []string{"PURGE"}}, &token); status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+	if token.Value == "" {
+		t.Fatalf("expected the token value on create")
+	}
+
+	req, _ := http.NewRequest("GET", s.URL+"/api/2.5/accounts/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token.Value)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the issued token to be accepted, got %d", resp.StatusCode)
+	}
+
+	if status := do(t, s, "DELETE", "/api/2.5/tokens/"+token.ID, nil, nil); status != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", status)
+	}
+	if len(s.Tokens()) != 0 {
+		t.Fatalf("expected the token to be revoked")
+	}
+
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected the revoked token to be rejected, got %d", resp.StatusCode)
+	}
+}
+
+func TestServerUnknownRoute(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	if status := do(t, s, "GET", "/api/2.5/unknown", nil, nil); status != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown route, got %d", status)
+	}
+}
+
+func TestServerRules(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	service := s.AddService(Service{Name: "Example", UniqueName: "example"})
+	path := "/api/2.6/services/" + service.ID + "/rules"
+
+	rules := map[string]interface{}{"rules": []map[string]interface{}{{
+		"name":       "legacy",
+		"enabled":    true,
+		"conditions": []map[string]interface{}{{"type": "PATH", "operator": "PREFIX", "values": []string{"/old"}}},
+		"actions":    []map[string]interface{}{{"type": "REDIRECT", "target": "https://example.com/new"}, {"type": "TTL_OVERRIDE", "ttl": 0}},
+	}}}
+	if status := do(t, s, "PUT", path, rules, nil); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+
+	var got rulesBody
+	do(t, s, "GET", path, nil, &got)
+	if len(got.Rules) != 1 || len(got.Rules[0].Actions) != 2 {
+		t.Fatalf("unexpected rules: %+v", got)
+	}
+	if code := got.Rules[0].Actions[0].StatusCode; code != http.StatusFound {
+		t.Errorf("expected the redirect to default to 302, got %d", code)
+	}
+	if ttl := got.Rules[0].Actions[1].TTL; ttl == nil || *ttl != 0 {
+		t.Errorf("expected an explicit ttl of 0 to be kept, got %v", ttl)
+	}
+
+	missingTTL := map[string]interface{}{"rules": []map[string]interface{}{{
+		"conditions": []map[string]interface{}{{"type": "PATH", "operator": "PREFIX", "values": []string{"/"}}},
+		"actions":    []map[string]interface{}{{"type": "TTL_OVERRIDE"}},
+	}}}
+	if status := do(t, s, "PUT", path, missingTTL, nil); status != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a TTL_OVERRIDE without ttl, got %d", status)
+	}
+
+	if status := do(t, s, "GET", "/api/2.6/services/missing/rules", nil, nil); status != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown service, got %d", status)
+	}
+}
+
+func TestServerCertificates(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	certPEM, keyPEM := testCertificate(t, "www.example.com")
+
+	var created Certificate
+	if status := do(t, s, "POST", "/api/2.5/certificates", map[string]string{"certificate": certPEM, "certificateKey": keyPEM}, &created); status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+	if created.SubjectCommonName != "www.example.com" || created.NotAfter == "" || created.CertificateKey != "" {
+		t.Fatalf("unexpected created certificate: %+v", created)
+	}
+
+	if status := do(t, s, "POST", "/api/2.5/certificates", map[string]string{"certificate": "garbage", "certificateKey": keyPEM}, nil); status != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an invalid certificate, got %d", status)
+	}
+
+	var page listResponse[Certificate]
+	do(t, s, "GET", "/api/2.5/certificates?search=example", nil, &page)
+	if page.Meta.Count != 1 {
+		t.Fatalf("expected 1 certificate, got %d", page.Meta.Count)
+	}
+
+	if status := do(t, s, "DELETE", "/api/2.5/certificates/"+created.ID, nil, nil); status != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", status)
+	}
+	if status := do(t, s, "GET", "/api/2.5/certificates/"+created.ID, nil, nil); status != http.StatusNotFound {
+		t.Fatalf("expected 404 after delete, got %d", status)
+	}
+}
+
+func TestServerPurges(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	service := s.AddService(Service{Name: "Example", UniqueName: "example"})
+	path := "/api/2.5/services/" + service.ID + "/purge"
+
+	var purge Purge
+	if status := do(t, s, "POST", path, map[string]interface{}{"paths": []string{"/index.html"}}, &purge); status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+	if purge.Status != "PENDING" {
+		t.Fatalf("expected a pending purge, got %q", purge.Status)
+	}
+
+	do(t, s, "GET", path+"/"+purge.ID, nil, &purge)
+	if purge.Status != "COMPLETED" || purge.CompletedAt == "" {
+		t.Fatalf("expected the purge to complete when polled, got %+v", purge)
+	}
+
+	s.PurgeStatus = "FAILED"
+	do(t, s, "POST", path, map[string]interface{}{"paths": []string{"/*"}}, &purge)
+	do(t, s, "GET", path+"/"+purge.ID, nil, &purge)
+	if purge.Status != "FAILED" {
+		t.Fatalf("expected the purge to fail, got %q", purge.Status)
+	}
+
+	if status := do(t, s, "POST", "/api/2.5/services/missing/purge", map[string]interface{}{"paths": []string{"/*"}}, nil); status != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown service, got %d", status)
+	}
+	if len(s.Purges()) != 2 {
+		t.Fatalf("expected 2 purges, got %d", len(s.Purges()))
+	}
+}
+
+func TestServerUsers(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	var user User
+	if status := do(t, s, "POST", "/api/2.5/users", map[string]interface{}{"email": "ops@example.com", "permissions": []string{"READ"}}, &user); status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+	if user.Status != "INVITED" {
+		t.Fatalf("expected an invited user, got %q", user.Status)
+	}
+	if status := do(t, s, "POST", "/api/2.5/users", map[string]interface{}{"email": "OPS@example.com"}, nil); status != http.StatusConflict {
+		t.Fatalf("duplicate email: expected 409, got %d", status)
+	}
+
+	do(t, s, "PUT", "/api/2.5/users/"+user.ID, map[string]interface{}{"fullName": "Ops", "permissions": []string{"READ", "PURGE"}}, &user)
+	if user.FullName != "Ops" || len(user.Permissions) != 2 || user.Email != "ops@example.com" {
+		t.Fatalf("unexpected updated user: %+v", user)
+	}
+
+	var page listResponse[User]
+	do(t, s, "GET", "/api/2.5/users?search=ops", nil, &page)
+	if page.Meta.Count != 1 {
+		t.Fatalf("expected 1 user, got %d", page.Meta.Count)
+	}
+
+	if status := do(t, s, "DELETE", "/api/2.5/users/"+user.ID, nil, nil); status != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", status)
+	}
+	if status := do(t, s, "GET", "/api/2.5/users/"+user.ID, nil, nil); status != http.StatusNotFound {
+		t.Fatalf("expected 404 after delete, got %d", status)
+	}
+}
+
+func TestServerLogTargets(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	var target LogTarget
+	body := map[string]interface{}{"name": "archive", "type": "S3", "bucket": "logs", "accessKey": "AKIA", "secretKey": "secret", "format": "JSON", "samplingRate": 1}
+	if status := do(t, s, "POST", "/api/2.5/logtargets", body, &target); status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+	if target.AccessKey != "" || target.SecretKey != "" {
+		t.Fatalf("expected credentials to be left out of the response, got %+v", target)
+	}
+	if stored := s.LogTargets(); len(stored) != 1 || stored[0].SecretKey != "secret" {
+		t.Fatalf("expected the credentials to be stored, got %+v", stored)
+	}
+
+	body["type"] = "GCS"
+	if status := do(t, s, "PUT", "/api/2.5/logtargets/"+target.ID, body, nil); status != http.StatusBadRequest {
+		t.Fatalf("type change: expected 400, got %d", status)
+	}
+
+	if status := do(t, s, "DELETE", "/api/2.5/logtargets/"+target.ID, nil, nil); status != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", status)
+	}
+	if status := do(t, s, "GET", "/api/2.5/logtargets/"+target.ID, nil, nil); status != http.StatusNotFound {
+		t.Fatalf("expected 404 after delete, got %d", status)
+	}
+}
+
+func TestServerReports(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	service := s.AddService(Service{Name: "Example", UniqueName: "example"})
+	s.AddService(Service{Name: "Other", UniqueName: "other"})
+
+	query := "?from=2024-01-01T00:00:00Z&to=2024-01-03T00:00:00Z&granularity=DAY"
+
+	var report Report
+	if status := do(t, s, "GET", "/api/2.5/reports/services/"+service.ID+query, nil, &report); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if len(report.Data) != 2 || report.Totals.Requests != 2*periodRequests || report.Totals.StatusCodes["404"] != 100 {
+		t.Fatalf("unexpected service report: %+v", report)
+	}
+
+	do(t, s, "GET", "/api/2.5/reports/account"+query, nil, &report)
+	if report.Totals.Requests != 4*periodRequests {
+		t.Fatalf("expected the account report to cover both services, got %d requests", report.Totals.Requests)
+	}
+
+	if status := do(t, s, "GET", "/api/2.5/reports/account?from=2024-01-01T00:00:00Z&to=2024-01-03T00:00:00Z&granularity=WEEK", nil, nil); status != http.StatusBadRequest {
+		t.Fatalf("unknown granularity: expected 400, got %d", status)
+	}
+}
+
+// testCertificate returns a self-signed certificate and its key, PEM encoded.
+func testCertificate(t *testing.T, hostname string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: hostname},
+		DNSNames:     []string{hostname},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestServerFaultInjection(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	s.InjectFault(Fault{Method: "GET", Path: "/api/2.5/accounts/*", Status: http.StatusServiceUnavailable, Times: 2})
+
+	for i, want := range []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK} {
+		if status := do(t, s, "GET", "/api/2.5/accounts/me", nil, nil); status != want {
+			t.Fatalf("request %d: expected %d, got %d", i, want, status)
+		}
+	}
+
+	if got := len(s.Requests()); got != 3 {
+		t.Fatalf("expected 3 recorded requests, got %d", got)
+	}
+}
+
+func TestServerFaultKinds(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	get := func() (*http.Response, error) {
+		req, _ := http.NewRequest("GET", s.URL+"/api/2.5/accounts/me", nil)
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+		return http.DefaultClient.Do(req)
+	}
+
+	s.InjectFault(Fault{Kind: FaultDrop, Path: "/api/2.5/accounts/me", Times: 1})
+	if resp, err := get(); err == nil {
+		resp.Body.Close()
+		t.Fatalf("expected a dropped connection, got status %d", resp.StatusCode)
+	}
+
+	s.InjectFault(Fault{Kind: FaultRateLimit, Path: "/api/2.5/accounts/me", RetryAfter: "7", Times: 1})
+	resp, err := get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "7" {
+		t.Fatalf("expected 429 with Retry-After 7, got %d %q", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+
+	s.InjectFault(Fault{Kind: FaultMalformedJSON, Path: "/api/2.5/accounts/me", Times: 1})
+	resp, err = get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var account Account
+	err = json.NewDecoder(resp.Body).Decode(&account)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || err == nil {
+		t.Fatalf("expected 200 with malformed JSON, got %d and decode error %v", resp.StatusCode, err)
+	}
+
+	s.InjectFault(Fault{Kind: FaultSlow, Path: "/api/2.5/accounts/me", Delay: 50 * time.Millisecond, Times: 1})
+	start := time.Now()
+	if status := do(t, s, "GET", "/api/2.5/accounts/me", nil, &account); status != http.StatusOK {
+		t.Fatalf("expected a slow fault to still succeed, got %d", status)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("expected the response to be delayed, took %s", elapsed)
+	}
+}
+
+func TestServerLatency(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	s.SetLatency(50 * time.Millisecond)
+
+	start := time.Now()
+	do(t, s, "GET", "/api/2.5/accounts/me", nil, nil)
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("expected the response to be delayed, took %s", elapsed)
+	}
+}
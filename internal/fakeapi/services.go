@@ -0,0 +1,448 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Service is a CDN service as returned by the API.
+type Service struct {
+	ID                string `json:"_id"`
+	Name              string `json:"name"`
+	UniqueName        string `json:"uniqueName"`
+	Description       string `json:"description,omitempty"`
+	AutoSsl           bool   `json:"autoSsl"`
+	ConfigurationMode string `json:"configurationMode"`
+	Status            string `json:"status"`
+	CreatedAt         string `json:"createdAt"`
+	UpdateAt          string `json:"updateAt"`
+}
+
+// Domain is a domain of a service as returned by the API.
+type Domain struct {
+	ID               string   `json:"_id"`
+	Name             string   `json:"name"`
+	Description      string   `json:"description,omitempty"`
+	Service          string   `json:"service"`
+	Certificates     []string `json:"certificates,omitempty"`
+	ValidationMode   string   `json:"validationMode"`
+	ValidationStatus string   `json:"validationStatus"`
+	CreatedAt        string   `json:"createdAt"`
+	UpdateAt         string   `json:"updateAt"`
+}
+
+// AddService stores a service as is, e.g. a DEACTIVATED service left behind by an earlier run.
+// The ID, status and timestamps are filled in when empty.
+func (s *Server) AddService(service Service) Service {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.addService(service)
+}
+
+// Service returns the stored service with the given ID.
+func (s *Server) Service(id string) (Service, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	service, ok := s.services[id]
+	if !ok {
+		return Service{}, false
+	}
+	return *service, true
+}
+
+// Services returns the stored services in creation order.
+func (s *Server) Services() []Service {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	services := make([]Service, 0, len(s.order))
+	for _, id := range s.order {
+		if service, ok := s.services[id]; ok {
+			services = append(services, *service)
+		}
+	}
+	return services
+}
+
+// Domains returns the stored domains of a service.
+func (s *Server) Domains(serviceID string) []Domain {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	domains := make([]Domain, 0, len(s.domains[serviceID]))
+	for _, domain := range s.domains[serviceID] {
+		domains = append(domains, *domain)
+	}
+	return domains
+}
+
+// Options returns the stored options of a service, keyed by option name.
+func (s *Server) Options(serviceID string) map[string]json.RawMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	options := make(map[string]json.RawMessage, len(s.options[serviceID]))
+	for key, value := range s.options[serviceID] {
+		options[key] = value
+	}
+	return options
+}
+
+// addService stores a service with its default domain and options. Callers must hold s.mu.
+func (s *Server) addService(service Service) *Service {
+	if service.ID == "" {
+		service.ID = s.newID()
+	}
+	if service.Status == "" {
+		service.Status = "ACTIVE"
+	}
+	if service.ConfigurationMode == "" {
+		service.ConfigurationMode = "API_RULES_AND_OPTIONS"
+	}
+	if service.CreatedAt == "" {
+		service.CreatedAt = now()
+	}
+	service.UpdateAt = service.CreatedAt
+
+	stored := &service
+	s.services[service.ID] = stored
+	s.order = append(s.order, service.ID)
+	s.domains[service.ID] = []*Domain{{
+		ID:               s.newID(),
+		Name:             service.UniqueName + ".cachefly.net",
+		Service:          service.ID,
+		ValidationMode:   "MANUAL",
+		ValidationStatus: "VALIDATED",
+		CreatedAt:        service.CreatedAt,
+		UpdateAt:         service.CreatedAt,
+	}}
+	s.options[service.ID] = map[string]json.RawMessage{
+		"reverseProxy": json.RawMessage(`{"enabled":false}`),
+		"edgetoorigin": json.RawMessage(`false`),
+	}
+	return stored
+}
+
+// lookupService returns the service named in the path, answering 404 when it does not exist.
+// Callers must hold s.mu.
+func (s *Server) lookupService(w http.ResponseWriter, r *http.Request) *Service {
+	service, ok := s.services[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("service %s not found", r.PathValue("id")))
+		return nil
+	}
+	return service
+}
+
+func (s *Server) listServices(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := r.URL.Query().Get("status")
+	services := make([]Service, 0, len(s.order))
+	for _, id := range s.order {
+		if service := s.services[id]; service != nil && (status == "" || service.Status == status) {
+			services = append(services, *service)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, paginate(r, services))
+}
+
+func (s *Server) createService(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name        string `json:"name"`
+		UniqueName  string `json:"uniqueName"`
+		Description string `json:"description"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Name == "" || body.UniqueName == "" {
+		writeError(w, http.StatusBadRequest, "name and uniqueName are required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, service := range s.services {
+		if service.UniqueName == body.UniqueName {
+			writeError(w, http.StatusConflict, fmt.Sprintf("uniqueName %s already exists", body.UniqueName))
+			return
+		}
+	}
+
+	service := s.addService(Service{Name: body.Name, UniqueName: body.UniqueName, Description: body.Description})
+	writeJSON(w, http.StatusCreated, service)
+}
+
+func (s *Server) getService(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if service := s.lookupService(w, r); service != nil {
+		writeJSON(w, http.StatusOK, service)
+	}
+}
+
+// updateService applies the fields present in the body. uniqueName cannot be changed.
+func (s *Server) updateService(w http.ResponseWriter, r *http.Request) {
+	var body map[string]json.RawMessage
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	service := s.lookupService(w, r)
+	if service == nil {
+		return
+	}
+
+	if raw, ok := body["name"]; ok {
+		var name string
+		if json.Unmarshal(raw, &name) == nil && name != "" {
+			service.Name = name
+		}
+	}
+	if raw, ok := body["description"]; ok {
+		json.Unmarshal(raw, &service.Description)
+	}
+	if raw, ok := body["autoSsl"]; ok {
+		if err := json.Unmarshal(raw, &service.AutoSsl); err != nil {
+			writeError(w, http.StatusBadRequest, "autoSsl must be a boolean")
+			return
+		}
+		if service.AutoSsl {
+			for _, domain := range s.domains[service.ID] {
+				s.issueCertificate(domain)
+			}
+		}
+	}
+	service.UpdateAt = now()
+
+	writeJSON(w, http.StatusOK, service)
+}
+
+func (s *Server) deleteService(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	service := s.lookupService(w, r)
+	if service == nil {
+		return
+	}
+
+	delete(s.services, service.ID)
+	delete(s.domains, service.ID)
+	delete(s.options, service.ID)
+	delete(s.rules, service.ID)
+	for i, id := range s.order {
+		if id == service.ID {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+
+	writeJSON(w, http.StatusOK, service)
+}
+
+func (s *Server) setServiceStatus(status string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		service := s.lookupService(w, r)
+		if service == nil {
+			return
+		}
+		service.Status = status
+		service.UpdateAt = now()
+
+		writeJSON(w, http.StatusOK, service)
+	}
+}
+
+func (s *Server) listDomains(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lookupService(w, r) == nil {
+		return
+	}
+
+	search := r.URL.Query().Get("search")
+	domains := make([]Domain, 0)
+	for _, domain := range s.domains[r.PathValue("id")] {
+		if search == "" || strings.Contains(domain.Name, search) {
+			domains = append(domains, *domain)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, paginate(r, domains))
+}
+
+type domainBody struct {
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	ValidationMode string   `json:"validationMode"`
+	Certificates   []string `json:"certificates"`
+}
+
+func (s *Server) createDomain(w http.ResponseWriter, r *http.Request) {
+	var body domainBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	service := s.lookupService(w, r)
+	if service == nil {
+		return
+	}
+	for _, domain := range s.domains[service.ID] {
+		if domain.Name == body.Name {
+			writeError(w, http.StatusConflict, fmt.Sprintf("domain %s already exists", body.Name))
+			return
+		}
+	}
+
+	domain := &Domain{
+		ID:               s.newID(),
+		Name:             body.Name,
+		Description:      body.Description,
+		Service:          service.ID,
+		Certificates:     body.Certificates,
+		ValidationMode:   body.ValidationMode,
+		ValidationStatus: s.DomainValidationStatus,
+		CreatedAt:        now(),
+	}
+	domain.UpdateAt = domain.CreatedAt
+	if service.AutoSsl {
+		s.issueCertificate(domain)
+	}
+	s.domains[service.ID] = append(s.domains[service.ID], domain)
+
+	writeJSON(w, http.StatusCreated, domain)
+}
+
+func (s *Server) updateDomain(w http.ResponseWriter, r *http.Request) {
+	var body domainBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	domain := s.lookupDomain(w, r)
+	if domain == nil {
+		return
+	}
+
+	domain.Description = body.Description
+	if body.ValidationMode != "" {
+		domain.ValidationMode = body.ValidationMode
+	}
+	if body.Certificates != nil {
+		domain.Certificates = body.Certificates
+	}
+	domain.UpdateAt = now()
+
+	writeJSON(w, http.StatusOK, domain)
+}
+
+func (s *Server) deleteDomain(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	domain := s.lookupDomain(w, r)
+	if domain == nil {
+		return
+	}
+
+	domains := s.domains[domain.Service]
+	for i, d := range domains {
+		if d.ID == domain.ID {
+			s.domains[domain.Service] = append(domains[:i], domains[i+1:]...)
+			break
+		}
+	}
+
+	writeJSON(w, http.StatusOK, domain)
+}
+
+// lookupDomain returns the domain named in the path. Callers must hold s.mu.
+func (s *Server) lookupDomain(w http.ResponseWriter, r *http.Request) *Domain {
+	if s.lookupService(w, r) == nil {
+		return nil
+	}
+	for _, domain := range s.domains[r.PathValue("id")] {
+		if domain.ID == r.PathValue("domainID") {
+			return domain
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("domain %s not found", r.PathValue("domainID")))
+	return nil
+}
+
+// issueCertificate gives a validated custom domain an AutoSSL certificate. Callers must hold s.mu.
+func (s *Server) issueCertificate(domain *Domain) {
+	if s.HoldCertificates || domain.ValidationStatus != "VALIDATED" || strings.HasSuffix(domain.Name, ".cachefly.net") || len(domain.Certificates) > 0 {
+		return
+	}
+	domain.Certificates = []string{s.newID()}
+}
+
+// getOptions returns the options of a service. Disabled sections other than reverseProxy are
+// omitted, as the API does.
+func (s *Server) getOptions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lookupService(w, r) == nil {
+		return
+	}
+
+	options := make(map[string]json.RawMessage)
+	for key, value := range s.options[r.PathValue("id")] {
+		var section struct {
+			Enabled *bool `json:"enabled"`
+		}
+		if key != "reverseProxy" && json.Unmarshal(value, &section) == nil && section.Enabled != nil && !*section.Enabled {
+			continue
+		}
+		options[key] = value
+	}
+
+	writeJSON(w, http.StatusOK, options)
+}
+
+// updateOptions replaces the option sections present in the body and leaves the others unchanged.
+func (s *Server) updateOptions(w http.ResponseWriter, r *http.Request) {
+	var body map[string]json.RawMessage
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lookupService(w, r) == nil {
+		return
+	}
+
+	options := s.options[r.PathValue("id")]
+	for key, value := range body {
+		options[key] = value
+	}
+
+	writeJSON(w, http.StatusOK, options)
+}
@@ -0,0 +1,100 @@
+package fakeapi
+
+import (
+	"net/http"
+	"time"
+)
+
+// Token is an API token as returned by the API. Value is only returned when the token is created.
+type Token struct {
+	ID        string   `json:"_id"`
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt string   `json:"expiresAt,omitempty"`
+	Value     string   `json:"token,omitempty"`
+	CreatedAt string   `json:"createdAt"`
+}
+
+// Tokens returns the API tokens that have been created and not revoked.
+func (s *Server) Tokens() []Token {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens := make([]Token, 0, len(s.tokens))
+	for _, token := range s.tokens {
+		tokens = append(tokens, *token)
+	}
+	return tokens
+}
+
+// validToken reports whether value is the server token or an API token it issued.
+// Callers must hold s.mu.
+func (s *Server) validToken(value string) bool {
+	if value == s.Token {
+		return true
+	}
+	for _, token := range s.tokens {
+		if token.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) createToken(w http.ResponseWriter, r *http.Request) {
+	var token Token
+	if !decodeBody(w, r, &token) {
+		return
+	}
+	if token.Name == "" || len(token.Scopes) == 0 {
+		writeError(w, http.StatusBadRequest, "name and scopes are required")
+		return
+	}
+	if token.ExpiresAt != "" {
+		// Like the API, store the expiry in UTC with milliseconds whatever format it was sent in.
+		expiresAt, err := time.Parse(time.RFC3339, token.ExpiresAt)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "expiresAt must be an RFC 3339 time")
+			return
+		}
+		token.ExpiresAt = expiresAt.UTC().Format("2006-01-02T15:04:05.000Z07:00")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token.ID = s.newID()
+	token.Value = "fake-api-token-" + token.ID
+	token.CreatedAt = now()
+	s.tokens = append(s.tokens, &token)
+
+	writeJSON(w, http.StatusCreated, token)
+}
+
+func (s *Server) getToken(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, token := range s.tokens {
+		if token.ID == r.PathValue("id") {
+			stored := *token
+			stored.Value = ""
+			writeJSON(w, http.StatusOK, stored)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "token not found")
+}
+
+func (s *Server) deleteToken(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, token := range s.tokens {
+		if token.ID == r.PathValue("id") {
+			s.tokens = append(s.tokens[:i], s.tokens[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "token not found")
+}
@@ -0,0 +1,141 @@
+package fakeapi
+
+import (
+	"net/http"
+	"strings"
+)
+
+// User is an account user as returned by the API.
+type User struct {
+	ID          string   `json:"_id"`
+	Email       string   `json:"email"`
+	FullName    string   `json:"fullName"`
+	Phone       string   `json:"phone,omitempty"`
+	Permissions []string `json:"permissions"`
+	Services    []string `json:"services"`
+	Status      string   `json:"status"`
+	CreatedAt   string   `json:"createdAt"`
+}
+
+// Users returns the users of the account.
+func (s *Server) Users() []User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	users := make([]User, 0, len(s.users))
+	for _, user := range s.users {
+		users = append(users, *user)
+	}
+	return users
+}
+
+func (s *Server) lookupUser(w http.ResponseWriter, r *http.Request) *User {
+	for _, user := range s.users {
+		if user.ID == r.PathValue("id") {
+			return user
+		}
+	}
+	writeError(w, http.StatusNotFound, "user not found")
+	return nil
+}
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	search := r.URL.Query().Get("search")
+	users := make([]User, 0, len(s.users))
+	for _, user := range s.users {
+		if search == "" || strings.Contains(user.Email, search) || strings.Contains(user.FullName, search) {
+			users = append(users, *user)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, paginate(r, users))
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	var user User
+	if !decodeBody(w, r, &user) {
+		return
+	}
+	if user.Email == "" {
+		writeError(w, http.StatusBadRequest, "email is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.users {
+		if strings.EqualFold(existing.Email, user.Email) {
+			writeError(w, http.StatusConflict, "a user with email "+user.Email+" already exists")
+			return
+		}
+	}
+
+	user.ID = s.newID()
+	user.Status = "INVITED"
+	user.CreatedAt = now()
+	if user.Permissions == nil {
+		user.Permissions = []string{}
+	}
+	if user.Services == nil {
+		user.Services = []string{}
+	}
+	s.users = append(s.users, &user)
+
+	writeJSON(w, http.StatusCreated, user)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user := s.lookupUser(w, r); user != nil {
+		writeJSON(w, http.StatusOK, *user)
+	}
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request) {
+	var update User
+	if !decodeBody(w, r, &update) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user := s.lookupUser(w, r)
+	if user == nil {
+		return
+	}
+	if update.Email != "" && !strings.EqualFold(update.Email, user.Email) {
+		writeError(w, http.StatusBadRequest, "email cannot be changed")
+		return
+	}
+
+	user.FullName = update.FullName
+	user.Phone = update.Phone
+	if update.Permissions != nil {
+		user.Permissions = update.Permissions
+	}
+	if update.Services != nil {
+		user.Services = update.Services
+	}
+
+	writeJSON(w, http.StatusOK, *user)
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, user := range s.users {
+		if user.ID == r.PathValue("id") {
+			s.users = append(s.users[:i], s.users[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "user not found")
+}
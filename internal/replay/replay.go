@@ -0,0 +1,266 @@
+// Package replay records HTTP interactions to a fixture file and replays them later, so tests
+// can exercise the provider against real API payloads without network access.
+//
+// A Transport is plugged into the http.Client of the provider. In record mode it forwards
+// requests to the real API and keeps every interaction, scrubbed of credentials, until Save
+// writes them out. In replay mode it answers requests from the fixture file and never touches
+// the network.
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces the value of every scrubbed field.
+const Redacted = "REDACTED"
+
+// sensitiveKeys are JSON fields whose values are never written to a fixture. Keys are
+// compared case-insensitively.
+var sensitiveKeys = map[string]bool{
+	"token":              true,
+	"accesskey":          true,
+	"secretkey":          true,
+	"secret":             true,
+	"secondarysecret":    true,
+	"password":           true,
+	"certificatekey":     true,
+	"serviceaccountjson": true,
+	"authorization":      true,
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Method       string          `json:"method"`
+	Path         string          `json:"path"`
+	Query        string          `json:"query,omitempty"`
+	RequestBody  json.RawMessage `json:"request_body,omitempty"`
+	Status       int             `json:"status"`
+	ResponseBody json.RawMessage `json:"response_body,omitempty"`
+	// ResponseText holds response bodies that are not JSON.
+	ResponseText string `json:"response_text,omitempty"`
+}
+
+type fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Transport is an http.RoundTripper that records or replays interactions.
+type Transport struct {
+	path      string
+	recording bool
+	next      http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewRecorder returns a Transport that sends requests through next and records them for Save.
+func NewRecorder(path string, next http.RoundTripper) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Transport{path: path, recording: true, next: next}
+}
+
+// NewReplayer returns a Transport that answers requests from the fixture file at path.
+func NewReplayer(path string) (*Transport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to decode fixture %s: %w", path, err)
+	}
+
+	return &Transport{path: path, interactions: f.Interactions, used: make([]bool, len(f.Interactions))}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		requestBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	if t.recording {
+		return t.record(req, requestBody)
+	}
+	return t.replay(req, requestBody)
+}
+
+func (t *Transport) record(req *http.Request, requestBody []byte) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	interaction := Interaction{
+		Method:      req.Method,
+		Path:        req.URL.Path,
+		Query:       req.URL.Query().Encode(),
+		RequestBody: Scrub(requestBody),
+		Status:      resp.StatusCode,
+	}
+	if json.Valid(responseBody) {
+		interaction.ResponseBody = Scrub(responseBody)
+	} else {
+		interaction.ResponseText = string(responseBody)
+	}
+
+	t.mu.Lock()
+	t.interactions = append(t.interactions, interaction)
+	t.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay answers with the first unused interaction matching the request.
+func (t *Transport) replay(req *http.Request, requestBody []byte) (*http.Response, error) {
+	query := req.URL.Query().Encode()
+	body := Scrub(requestBody)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, interaction := range t.interactions {
+		if t.used[i] || interaction.Method != req.Method || interaction.Path != req.URL.Path || interaction.Query != query {
+			continue
+		}
+		if !jsonEqual(interaction.RequestBody, body) {
+			continue
+		}
+		t.used[i] = true
+
+		responseBody := []byte(interaction.ResponseBody)
+		if interaction.ResponseText != "" {
+			responseBody = []byte(interaction.ResponseText)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Status, http.StatusText(interaction.Status)),
+			StatusCode:    interaction.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          io.NopCloser(bytes.NewReader(responseBody)),
+			ContentLength: int64(len(responseBody)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction in %s matches %s %s?%s", filepath.Base(t.path), req.Method, req.URL.Path, query)
+}
+
+// Unused returns the recorded interactions that were never replayed.
+func (t *Transport) Unused() []Interaction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var unused []Interaction
+	for i, interaction := range t.interactions {
+		if !t.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// Save writes the recorded interactions to the fixture file. It does nothing in replay mode.
+func (t *Transport) Save() error {
+	if !t.recording {
+		return nil
+	}
+
+	t.mu.Lock()
+	data, err := json.MarshalIndent(fixture{Interactions: t.interactions}, "", "  ")
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(t.path, append(data, '\n'), 0o644)
+}
+
+// Scrub returns a JSON body with the values of sensitive fields replaced by Redacted.
+// Empty bodies return nil and bodies that are not JSON are returned unchanged.
+func Scrub(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		quoted, _ := json.Marshal(string(body))
+		return quoted
+	}
+
+	scrubbed, err := json.Marshal(scrubValue(v))
+	if err != nil {
+		return body
+	}
+	return scrubbed
+}
+
+func scrubValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if sensitiveKeys[strings.ToLower(key)] {
+				if s, ok := item.(string); ok && s != "" {
+					value[key] = Redacted
+				}
+				continue
+			}
+			value[key] = scrubValue(item)
+		}
+		return value
+	case []interface{}:
+		for i, item := range value {
+			value[i] = scrubValue(item)
+		}
+		return value
+	default:
+		return v
+	}
+}
+
+func jsonEqual(a, b json.RawMessage) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return bytes.Equal(a, b)
+	}
+	na, _ := json.Marshal(va)
+	nb, _ := json.Marshal(vb)
+	return bytes.Equal(na, nb)
+}
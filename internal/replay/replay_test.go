@@ -0,0 +1,84 @@
+package replay
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScrub(t *testing.T) {
+	got := Scrub([]byte(`{"name":"svc","reverseProxy":{"accessKey":"AKIA","secretKey":"s3cr3t","region":"us-east-1"},"tokens":[{"token":"abc"}]}`))
+
+	for _, secret := range []string{"AKIA", "s3cr3t", "abc"} {
+		if strings.Contains(string(got), secret) {
+			t.Errorf("scrubbed body still contains %q: %s", secret, got)
+		}
+	}
+	if !strings.Contains(string(got), `"region":"us-east-1"`) {
+		t.Errorf("scrubbing removed a non-sensitive field: %s", got)
+	}
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer real-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"_id":"1","companyName":"Example","token":"leaked"}`)
+	}))
+	defer upstream.Close()
+
+	path := filepath.Join(t.TempDir(), "account.json")
+
+	recorder := NewRecorder(path, nil)
+	client := &http.Client{Transport: recorder}
+	req, _ := http.NewRequest("GET", upstream.URL+"/api/2.5/accounts/me?responseType=full", nil)
+	req.Header.Set("Authorization", "Bearer real-token")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "real-token") || strings.Contains(string(data), "leaked") {
+		t.Fatalf("fixture contains credentials: %s", data)
+	}
+
+	replayer, err := NewReplayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = &http.Client{Transport: replayer}
+
+	resp, err = client.Get("https://api.invalid/api/2.5/accounts/me?responseType=full")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var account map[string]string
+	if err := json.NewDecoder(resp.Body).Decode(&account); err != nil {
+		t.Fatal(err)
+	}
+	if account["companyName"] != "Example" {
+		t.Fatalf("unexpected replayed body: %v", account)
+	}
+	if len(replayer.Unused()) != 0 {
+		t.Fatalf("expected every interaction to be replayed")
+	}
+
+	if _, err := client.Get("https://api.invalid/api/2.5/accounts/me?responseType=full"); err == nil {
+		t.Fatalf("expected an error once the interaction has been used")
+	}
+}
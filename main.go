@@ -1,12 +1,40 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
 	"github.com/AlehYarmalovich/terraform-provider-cachefly/cachefly"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 )
 
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: cachefly.Provider,
-	})
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		if err := runGenerate(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	var debug bool
+	flag.BoolVar(&debug, "debug", false, "start the provider in debug mode for use with delve")
+	flag.Parse()
+
+	providerServer, err := cachefly.ProviderServer(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serveOpts []tf5server.ServeOpt
+	if debug {
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
+	}
+
+	if err := tf5server.Serve("registry.terraform.io/alehyarmalovich/cachefly", providerServer, serveOpts...); err != nil {
+		log.Fatal(err)
+	}
 }